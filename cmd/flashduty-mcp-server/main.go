@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/flashcatcloud/flashduty-mcp-server/internal/flashduty"
 	flashdutyPkg "github.com/flashcatcloud/flashduty-mcp-server/pkg/flashduty"
+	mcplog "github.com/flashcatcloud/flashduty-mcp-server/pkg/log"
 )
 
 // These variables are set by the build process using ldflags.
@@ -55,6 +57,11 @@ var (
 				}
 			}
 
+			redaction, err := redactionConfig()
+			if err != nil {
+				return err
+			}
+
 			stdioServerConfig := flashduty.StdioServerConfig{
 				Version:              version,
 				BaseURL:              viper.GetString("base_url"),
@@ -65,6 +72,13 @@ var (
 				ExportTranslations:   viper.GetBool("export-translations"),
 				EnableCommandLogging: viper.GetBool("enable-command-logging"),
 				LogFilePath:          viper.GetString("log-file"),
+				LogFormat:            viper.GetString("log-format"),
+				LogLevel:             viper.GetString("log-level"),
+				OTLPEndpoint:         viper.GetString("otlp-endpoint"),
+				Redaction:            redaction,
+				AuditLog:             viper.GetString("audit-log"),
+				Retry:                retryConfig(),
+				Cache:                cacheConfig(),
 			}
 			return flashduty.RunStdioServer(stdioServerConfig)
 		},
@@ -75,14 +89,41 @@ var (
 		Short: "Start HTTP server",
 		Long:  `Start a streamable HTTP server.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
+			// Same comma-separated env var caveat as toolsets above.
+			var allowedBaseURLs []string
+			if viper.IsSet("allowed-base-urls") {
+				allowedVal := viper.Get("allowed-base-urls")
+				if s, ok := allowedVal.(string); ok {
+					allowedBaseURLs = strings.Split(s, ",")
+				} else if sl, ok := allowedVal.([]string); ok {
+					allowedBaseURLs = sl
+				} else {
+					return fmt.Errorf("failed to parse 'allowed-base-urls': unexpected type %T", allowedVal)
+				}
+			}
+
+			redaction, err := redactionConfig()
+			if err != nil {
+				return err
+			}
+
 			httpServerConfig := flashduty.HTTPServerConfig{
-				Version:      version,
-				Commit:       commit,
-				Date:         date,
-				BaseURL:      viper.GetString("base_url"),
-				Port:         viper.GetString("port"),
-				OutputFormat: viper.GetString("output-format"),
-				LogFilePath:  viper.GetString("log-file"),
+				Version:         version,
+				Commit:          commit,
+				Date:            date,
+				BaseURL:         viper.GetString("base_url"),
+				AllowedBaseURLs: allowedBaseURLs,
+				Port:            viper.GetString("port"),
+				ShutdownDrain:   viper.GetDuration("shutdown-drain"),
+				OutputFormat:    viper.GetString("output-format"),
+				LogFilePath:     viper.GetString("log-file"),
+				LogFormat:       viper.GetString("log-format"),
+				LogLevel:        viper.GetString("log-level"),
+				OTLPEndpoint:    viper.GetString("otlp-endpoint"),
+				Redaction:       redaction,
+				AuditLog:        viper.GetString("audit-log"),
+				Retry:           retryConfig(),
+				Cache:           cacheConfig(),
 			}
 			return flashduty.RunHTTPServer(httpServerConfig)
 		},
@@ -98,14 +139,27 @@ func init() {
 	rootCmd.PersistentFlags().String("app-key", "", "Flashduty APP key (can also be set via FLASHDUTY_APP_KEY environment variable)")
 	rootCmd.PersistentFlags().StringSlice("toolsets", flashdutyPkg.DefaultTools, "An optional comma separated list of groups of tools to allow, defaults to enabling all")
 	rootCmd.PersistentFlags().Bool("read-only", false, "Restrict the server to read-only operations")
-	rootCmd.PersistentFlags().String("output-format", "json", "Output format for tool results: json (default) or toon (Token-Oriented Object Notation for reduced token usage)")
+	rootCmd.PersistentFlags().String("output-format", "json", "Output format for tool results: json (default), toon (Token-Oriented Object Notation for reduced token usage), markdown or csv")
 	rootCmd.PersistentFlags().String("log-file", "", "Path to log file")
+	rootCmd.PersistentFlags().String("log-format", "text", "Log format: text (default) or json (newline-delimited, for log pipelines)")
+	rootCmd.PersistentFlags().String("log-level", "", "Minimum log level: debug, info, warn or error (defaults to debug with --log-file, info otherwise)")
 	rootCmd.PersistentFlags().Bool("enable-command-logging", false, "When enabled, the server will log all command requests and responses to the log file")
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
 	rootCmd.PersistentFlags().String("base-url", "https://api.flashcat.cloud", "Specify the Flashduty API base URL")
+	rootCmd.PersistentFlags().StringArray("redact-pattern", nil, "Regular expression whose matches are redacted from logs, in addition to the built-in rules (app_key, bearer tokens, emails, phone numbers). Repeat for multiple patterns")
+	rootCmd.PersistentFlags().StringSlice("redact-paths", nil, "Comma separated dotted JSON paths (e.g. params.arguments.description, incidents.description; * matches any key) whose values are redacted from logs")
+	rootCmd.PersistentFlags().String("audit-log", "", "Record every write tool call in a hash-chained audit trail: a JSONL file path, or \"syslog\"; off when empty")
+	rootCmd.PersistentFlags().Int("retry-max-attempts", 3, "Maximum attempts per Flashduty API call, including the first; 1 disables retries")
+	rootCmd.PersistentFlags().Duration("retry-budget", 20*time.Second, "Maximum total time spent on one Flashduty API call across retries")
+	rootCmd.PersistentFlags().Bool("retry-writes", false, "Also retry write API calls that carry an idempotency key (_meta.idempotency_key on the tool call)")
+	rootCmd.PersistentFlags().Duration("cache-ttl", 5*time.Minute, "How long members, teams, channels and fields looked up by tools are cached per APP key; 0 disables the cache")
+	rootCmd.PersistentFlags().Int("cache-size", 1000, "Maximum number of cached reference-data lookups per APP key")
+	rootCmd.PersistentFlags().String("otlp-endpoint", "", "OTLP/HTTP collector URL to export trace spans to (e.g. http://localhost:4318); tracing export is off when empty")
 
 	// Add flags for http command
 	httpCmd.Flags().String("port", "11310", "Port to listen on")
+	httpCmd.Flags().Duration("shutdown-drain", 5*time.Second, "How long to keep serving after SIGTERM, with /readyz returning 503, before closing the listener; set longer than the readiness probe period")
+	httpCmd.Flags().StringSlice("allowed-base-urls", nil, "Comma separated origins clients may select with the base_url query parameter, in addition to --base-url. Use https://*.example.com to allow subdomains")
 
 	// Bind flag to viper
 	_ = viper.BindPFlag("app_key", rootCmd.PersistentFlags().Lookup("app-key"))
@@ -113,16 +167,79 @@ func init() {
 	_ = viper.BindPFlag("read-only", rootCmd.PersistentFlags().Lookup("read-only"))
 	_ = viper.BindPFlag("output-format", rootCmd.PersistentFlags().Lookup("output-format"))
 	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
+	_ = viper.BindPFlag("log-format", rootCmd.PersistentFlags().Lookup("log-format"))
+	_ = viper.BindPFlag("log-level", rootCmd.PersistentFlags().Lookup("log-level"))
 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
 	_ = viper.BindPFlag("base_url", rootCmd.PersistentFlags().Lookup("base-url"))
+	_ = viper.BindPFlag("redact-pattern", rootCmd.PersistentFlags().Lookup("redact-pattern"))
+	_ = viper.BindPFlag("redact-paths", rootCmd.PersistentFlags().Lookup("redact-paths"))
+	_ = viper.BindPFlag("otlp-endpoint", rootCmd.PersistentFlags().Lookup("otlp-endpoint"))
+	_ = viper.BindPFlag("audit-log", rootCmd.PersistentFlags().Lookup("audit-log"))
+	_ = viper.BindPFlag("retry-max-attempts", rootCmd.PersistentFlags().Lookup("retry-max-attempts"))
+	_ = viper.BindPFlag("retry-budget", rootCmd.PersistentFlags().Lookup("retry-budget"))
+	_ = viper.BindPFlag("retry-writes", rootCmd.PersistentFlags().Lookup("retry-writes"))
+	_ = viper.BindPFlag("cache-ttl", rootCmd.PersistentFlags().Lookup("cache-ttl"))
+	_ = viper.BindPFlag("cache-size", rootCmd.PersistentFlags().Lookup("cache-size"))
 	_ = viper.BindPFlag("port", httpCmd.Flags().Lookup("port"))
+	_ = viper.BindPFlag("allowed-base-urls", httpCmd.Flags().Lookup("allowed-base-urls"))
+	_ = viper.BindPFlag("shutdown-drain", httpCmd.Flags().Lookup("shutdown-drain"))
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
 	rootCmd.AddCommand(httpCmd)
 }
 
+// redactionConfig reads the user-defined log redaction rules. Patterns are
+// never split on commas, since regular expressions may contain them; set a
+// single pattern through FLASHDUTY_REDACT_PATTERN or repeat --redact-pattern.
+func redactionConfig() (mcplog.RedactionConfig, error) {
+	var cfg mcplog.RedactionConfig
+	if viper.IsSet("redact-pattern") {
+		switch v := viper.Get("redact-pattern").(type) {
+		case string:
+			cfg.Patterns = []string{v}
+		case []string:
+			cfg.Patterns = v
+		default:
+			return cfg, fmt.Errorf("failed to parse 'redact-pattern': unexpected type %T", v)
+		}
+	}
+	if viper.IsSet("redact-paths") {
+		switch v := viper.Get("redact-paths").(type) {
+		case string:
+			cfg.Paths = strings.Split(v, ",")
+		case []string:
+			cfg.Paths = v
+		default:
+			return cfg, fmt.Errorf("failed to parse 'redact-paths': unexpected type %T", v)
+		}
+	}
+	return cfg, nil
+}
+
+// retryConfig reads the Flashduty API retry policy flags.
+func retryConfig() flashduty.RetryConfig {
+	return flashduty.RetryConfig{
+		MaxAttempts: viper.GetInt("retry-max-attempts"),
+		Budget:      viper.GetDuration("retry-budget"),
+		RetryWrites: viper.GetBool("retry-writes"),
+	}
+}
+
+// cacheConfig reads the reference-data cache settings. A zero --cache-ttl
+// disables the cache rather than selecting the default.
+func cacheConfig() flashduty.CacheConfig {
+	ttl := viper.GetDuration("cache-ttl")
+	if ttl == 0 {
+		ttl = -1
+	}
+	return flashduty.CacheConfig{
+		TTL:  ttl,
+		Size: viper.GetInt("cache-size"),
+	}
+}
+
 func initConfig() {
 	// Initialize Viper configuration
 	viper.SetEnvPrefix("flashduty")
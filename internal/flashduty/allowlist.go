@@ -0,0 +1,117 @@
+package flashduty
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// baseURLAllowlist decides which `?base_url=` values an HTTP caller may point
+// the server at. getClient sends the caller's APP key to whatever base URL ends
+// up in the config, so without this check the parameter is both an SSRF vector
+// and a way to exfiltrate keys to a host the caller controls.
+//
+// Entries are origins (scheme://host[:port]). An entry whose host starts with
+// "*." matches any subdomain of the remainder but not the bare domain itself,
+// e.g. "https://*.flashcat.cloud" allows "https://api.flashcat.cloud".
+type baseURLAllowlist struct {
+	origins   map[string]struct{}
+	wildcards []wildcardOrigin
+}
+
+type wildcardOrigin struct {
+	scheme string
+	suffix string // ".example.com" or ".example.com:8443"
+}
+
+// newBaseURLAllowlist builds an allowlist from the server's default base URL,
+// which is always permitted, plus the configured patterns.
+func newBaseURLAllowlist(defaultBaseURL string, patterns []string) (*baseURLAllowlist, error) {
+	a := &baseURLAllowlist{origins: make(map[string]struct{})}
+	if defaultBaseURL != "" {
+		origin, err := parseOrigin(defaultBaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base URL %q: %w", defaultBaseURL, err)
+		}
+		a.origins[origin] = struct{}{}
+	}
+
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		scheme, host, ok := strings.Cut(p, "://")
+		if ok && strings.HasPrefix(host, "*.") {
+			scheme = strings.ToLower(scheme)
+			if scheme != "http" && scheme != "https" {
+				return nil, fmt.Errorf("invalid allowed base URL %q: scheme must be http or https", p)
+			}
+			suffix := strings.ToLower(strings.TrimSuffix(host[1:], "/"))
+			if len(suffix) < 2 || strings.ContainsAny(suffix, "/*?#@") {
+				return nil, fmt.Errorf("invalid allowed base URL %q: expected scheme://*.domain", p)
+			}
+			a.wildcards = append(a.wildcards, wildcardOrigin{scheme: scheme, suffix: suffix})
+			continue
+		}
+		origin, err := parseOrigin(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed base URL %q: %w", p, err)
+		}
+		a.origins[origin] = struct{}{}
+	}
+	return a, nil
+}
+
+// Allows reports whether raw is an acceptable base URL.
+func (a *baseURLAllowlist) Allows(raw string) bool {
+	origin, err := parseOrigin(raw)
+	if err != nil {
+		return false
+	}
+	if _, ok := a.origins[origin]; ok {
+		return true
+	}
+	scheme, host, _ := strings.Cut(origin, "://")
+	for _, w := range a.wildcards {
+		if scheme == w.scheme && strings.HasSuffix(host, w.suffix) && len(host) > len(w.suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseOrigin normalizes a base URL to its lowercase scheme://host[:port]
+// origin. Anything beyond an origin (credentials, query, fragment or a path
+// other than "/") is rejected rather than silently dropped, so the value that
+// passes the check is exactly the value the client will use.
+func parseOrigin(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", err
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return "", fmt.Errorf("scheme must be http or https")
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("missing host")
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" || (u.Path != "" && u.Path != "/") {
+		return "", fmt.Errorf("must be a bare origin (scheme://host[:port])")
+	}
+	return scheme + "://" + strings.ToLower(u.Host), nil
+}
+
+// requireAllowedBaseURL rejects requests whose `?base_url=` is not on the
+// allowlist with a 400, before the MCP handler builds a client for it.
+func requireAllowedBaseURL(next http.Handler, allowlist *baseURLAllowlist) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if baseURL := r.URL.Query().Get("base_url"); baseURL != "" && !allowlist.Allows(baseURL) {
+			http.Error(w, fmt.Sprintf("base_url %q is not allowed by this server; see --allowed-base-urls", baseURL), http.StatusBadRequest)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
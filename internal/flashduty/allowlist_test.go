@@ -0,0 +1,81 @@
+package flashduty
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBaseURLAllowlist(t *testing.T) {
+	t.Parallel()
+
+	allowlist, err := newBaseURLAllowlist("https://api.flashcat.cloud", []string{
+		"https://flashduty.internal:8443",
+		"https://*.example.com",
+	})
+	if err != nil {
+		t.Fatalf("newBaseURLAllowlist: %v", err)
+	}
+
+	tests := []struct {
+		baseURL string
+		want    bool
+	}{
+		{"https://api.flashcat.cloud", true},
+		{"https://API.flashcat.cloud/", true},
+		{"https://flashduty.internal:8443", true},
+		{"https://a.example.com", true},
+		{"https://a.b.example.com", true},
+		{"https://example.com", false},
+		{"http://a.example.com", false},
+		{"https://evil-example.com", false},
+		{"https://flashduty.internal", false},
+		{"http://169.254.169.254", false},
+		{"https://api.flashcat.cloud/path", false},
+		{"https://user@api.flashcat.cloud", false},
+		{"not a url", false},
+	}
+	for _, tt := range tests {
+		if got := allowlist.Allows(tt.baseURL); got != tt.want {
+			t.Errorf("Allows(%q) = %v, want %v", tt.baseURL, got, tt.want)
+		}
+	}
+}
+
+func TestNewBaseURLAllowlist_RejectsInvalidPatterns(t *testing.T) {
+	t.Parallel()
+
+	for _, pattern := range []string{"ftp://example.com", "https://*.", "example.com", "https://*.example.com/path"} {
+		if _, err := newBaseURLAllowlist("", []string{pattern}); err == nil {
+			t.Errorf("expected error for pattern %q", pattern)
+		}
+	}
+}
+
+func TestRequireAllowedBaseURL(t *testing.T) {
+	t.Parallel()
+
+	allowlist, err := newBaseURLAllowlist("https://api.flashcat.cloud", nil)
+	if err != nil {
+		t.Fatalf("newBaseURLAllowlist: %v", err)
+	}
+	handler := requireAllowedBaseURL(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), allowlist)
+
+	tests := []struct {
+		target string
+		want   int
+	}{
+		{"/mcp", http.StatusOK},
+		{"/mcp?base_url=https://api.flashcat.cloud", http.StatusOK},
+		{"/mcp?base_url=http://10.0.0.1", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.target, nil))
+		if rec.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.target, rec.Code, tt.want)
+		}
+	}
+}
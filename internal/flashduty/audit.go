@@ -0,0 +1,225 @@
+package flashduty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/audit"
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/flashduty"
+	mcplog "github.com/flashcatcloud/flashduty-mcp-server/pkg/log"
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/trace"
+)
+
+// auditSyslog selects the syslog sink for --audit-log instead of a file path.
+const auditSyslog = "syslog"
+
+// affectedIDKeys are the argument and result fields that name the objects a
+// write tool changed, recorded under the field name with any plural "s"
+// dropped.
+var affectedIDKeys = []string{"incident_id", "incident_ids", "target_incident_id", "source_incident_ids", "page_id", "change_id", "change_key", "alert_id"}
+
+// newAuditLogger opens the audit sink named by target: "syslog", or the path of
+// a JSONL file. An empty target disables auditing.
+func newAuditLogger(target string) (*audit.Logger, error) {
+	switch target = strings.TrimSpace(target); target {
+	case "":
+		return nil, nil
+	case auditSyslog:
+		return audit.NewSyslogLogger("flashduty-mcp-server")
+	default:
+		return audit.NewFileLogger(target)
+	}
+}
+
+// auditMiddleware records every call to a write tool (one without the
+// read-only hint) in the audit trail, whatever its outcome. A failure to write
+// the entry is logged but does not change the tool result: by then the change
+// has already been made.
+func auditMiddleware(cfg FlashdutyConfig) server.ToolHandlerMiddleware {
+	if cfg.Redactor == nil {
+		cfg.Redactor = mcplog.DefaultRedactor()
+	}
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !isWriteTool(ctx, request.Params.Name) {
+				return next(ctx, request)
+			}
+
+			start := time.Now()
+			ctx, resultValue := flashduty.ContextWithResultCapture(ctx)
+			result, err := next(ctx, request)
+
+			entry := auditEntry(ctx, cfg, request, result, resultValue(), err)
+			entry.Time = start.UTC()
+			entry.DurationMillis = time.Since(start).Milliseconds()
+			if logErr := cfg.Auditor.Log(entry); logErr != nil {
+				slog.ErrorContext(ctx, "failed to write audit entry", "tool", request.Params.Name, "error", logErr)
+			}
+			return result, err
+		}
+	}
+}
+
+// isWriteTool reports whether the named tool may change state. Tools without
+// annotations are treated as writes.
+func isWriteTool(ctx context.Context, name string) bool {
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return false
+	}
+	tool := srv.GetTool(name)
+	if tool == nil {
+		return false
+	}
+	hint := tool.Tool.Annotations.ReadOnlyHint
+	return hint == nil || !*hint
+}
+
+// auditEntry describes one finished write tool call. value is what the tool
+// passed to MarshalResult, read instead of the result text so affected IDs
+// are found whatever the output format.
+func auditEntry(ctx context.Context, cfg FlashdutyConfig, request mcp.CallToolRequest, result *mcp.CallToolResult, value any, err error) audit.Entry {
+	appKey := cfg.APPKey
+	if sessionCfg, ok := ConfigFromContext(ctx); ok {
+		appKey = sessionCfg.APPKey
+	}
+
+	entry := audit.Entry{
+		APPKeyFingerprint: audit.Fingerprint(appKey),
+		Tool:              request.Params.Name,
+		Arguments:         normalizeAuditArgs(request.GetArguments(), cfg),
+		AffectedIDs:       map[string][]string{},
+		Outcome:           audit.OutcomeSuccess,
+	}
+	if tc := trace.FromContext(ctx); tc != nil {
+		entry.TraceID = tc.TraceID
+	}
+	if session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithClientInfo); ok {
+		info := session.GetClientInfo()
+		entry.Client = audit.Client{Name: info.Name, Version: info.Version}
+	}
+
+	collectAffectedIDs(entry.AffectedIDs, request.GetArguments())
+	switch {
+	case err != nil:
+		entry.Outcome = audit.OutcomeError
+		entry.Error = cfg.Redactor.Redact(err.Error())
+	case result == nil:
+	case result.IsError:
+		entry.Outcome = audit.OutcomeError
+		entry.Error = cfg.Redactor.Redact(resultText(result))
+	default:
+		if out, ok := resultFields(value); ok {
+			collectAffectedIDs(entry.AffectedIDs, out)
+		}
+	}
+	return entry
+}
+
+// normalizeAuditArgs trims string arguments, drops empty ones and applies the
+// log redaction rules, so equivalent calls produce identical entries and
+// secrets passed as arguments stay out of the trail.
+func normalizeAuditArgs(args map[string]any, cfg FlashdutyConfig) map[string]any {
+	normalized := make(map[string]any, len(args))
+	for k, v := range args {
+		if s, ok := v.(string); ok {
+			if s = strings.TrimSpace(s); s == "" {
+				continue
+			}
+			v = s
+		}
+		if v == nil {
+			continue
+		}
+		normalized[k] = v
+	}
+
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		return normalized
+	}
+	var redacted map[string]any
+	if err := json.Unmarshal([]byte(cfg.Redactor.Redact(string(data))), &redacted); err != nil {
+		return normalized
+	}
+	return redacted
+}
+
+// collectAffectedIDs adds the IDs held by the affectedIDKeys fields of fields.
+// Plural fields may be comma-separated strings or arrays.
+func collectAffectedIDs(ids map[string][]string, fields map[string]any) {
+	for _, key := range affectedIDKeys {
+		v, ok := fields[key]
+		if !ok {
+			continue
+		}
+		name := strings.TrimSuffix(key, "s")
+		for _, id := range idStrings(v) {
+			if !slices.Contains(ids[name], id) {
+				ids[name] = append(ids[name], id)
+			}
+		}
+	}
+}
+
+// idStrings flattens an ID argument or result value to strings.
+func idStrings(v any) []string {
+	switch x := v.(type) {
+	case string:
+		var out []string
+		for _, part := range strings.Split(x, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+		return out
+	case float64:
+		return []string{strconv.FormatFloat(x, 'f', -1, 64)}
+	case int, int64, uint64:
+		return []string{fmt.Sprint(x)}
+	case []any:
+		var out []string
+		for _, item := range x {
+			out = append(out, idStrings(item)...)
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// resultText concatenates the text content of a tool result.
+func resultText(result *mcp.CallToolResult) string {
+	var b strings.Builder
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			b.WriteString(text.Text)
+		}
+	}
+	return b.String()
+}
+
+// resultFields converts a tool's result value to its JSON object form.
+func resultFields(value any) (map[string]any, bool) {
+	if value == nil {
+		return nil, false
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, false
+	}
+	return out, true
+}
@@ -0,0 +1,144 @@
+package flashduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/audit"
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/trace"
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
+)
+
+func TestAuditMiddleware_RecordsWriteTools(t *testing.T) {
+	t.Parallel()
+
+	var trail bytes.Buffer
+	cfg := FlashdutyConfig{APPKey: "secret-key", Auditor: audit.NewLogger(&trail, "")}
+
+	mcpServer := server.NewMCPServer("test", "test", server.WithToolHandlerMiddleware(auditMiddleware(cfg)))
+	mcpServer.AddTool(
+		mcp.NewTool("close_incident", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: mcp.ToBoolPtr(false)})),
+		func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(`{"status":"success"}`), nil
+		},
+	)
+	mcpServer.AddTool(
+		mcp.NewTool("create_incident", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: mcp.ToBoolPtr(false)})),
+		func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultError("Unable to create incident: forbidden"), nil
+		},
+	)
+	mcpServer.AddTool(
+		mcp.NewTool("query_incidents", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: mcp.ToBoolPtr(true)})),
+		func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(`{"items":[]}`), nil
+		},
+	)
+
+	session := server.NewInProcessSession("audit-session", nil)
+	session.Initialize()
+	session.SetClientInfo(mcp.Implementation{Name: "test-agent", Version: "2.1"})
+	if err := mcpServer.RegisterSession(context.Background(), session); err != nil {
+		t.Fatalf("register session: %v", err)
+	}
+	ctx := trace.ContextWithTraceContext(mcpServer.WithContext(context.Background(), session),
+		&trace.TraceContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"})
+
+	for _, msg := range []string{
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"close_incident","arguments":{"incident_ids":" a, b ","app_key":"leak"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"query_incidents","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"create_incident","arguments":{"title":"db down","description":""}}}`,
+	} {
+		mcpServer.HandleMessage(ctx, json.RawMessage(msg))
+	}
+
+	if n, err := audit.Verify(bytes.NewReader(trail.Bytes())); err != nil || n != 2 {
+		t.Fatalf("Verify = %d, %v; want 2 entries (reads are not audited)", n, err)
+	}
+	if s := trail.String(); strings.Contains(s, "secret-key") || strings.Contains(s, "leak") {
+		t.Fatalf("secrets leaked into the audit trail: %s", s)
+	}
+
+	lines := strings.Split(strings.TrimSpace(trail.String()), "\n")
+	var closed, created audit.Entry
+	if err := json.Unmarshal([]byte(lines[0]), &closed); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &created); err != nil {
+		t.Fatal(err)
+	}
+
+	if closed.Tool != "close_incident" || closed.Outcome != audit.OutcomeSuccess {
+		t.Errorf("close entry = %+v", closed)
+	}
+	if closed.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || closed.APPKeyFingerprint != audit.Fingerprint("secret-key") {
+		t.Errorf("trace/fingerprint = %q/%q", closed.TraceID, closed.APPKeyFingerprint)
+	}
+	if closed.Client != (audit.Client{Name: "test-agent", Version: "2.1"}) {
+		t.Errorf("client = %+v", closed.Client)
+	}
+	if got := strings.Join(closed.AffectedIDs["incident_id"], ","); got != "a,b" {
+		t.Errorf("affected incident IDs = %q", got)
+	}
+	if closed.Arguments["incident_ids"] != "a, b" {
+		t.Errorf("arguments = %v", closed.Arguments)
+	}
+
+	if created.Outcome != audit.OutcomeError || !strings.Contains(created.Error, "forbidden") {
+		t.Errorf("create entry = %+v", created)
+	}
+	if _, ok := created.Arguments["description"]; ok {
+		t.Errorf("empty arguments should be dropped: %v", created.Arguments)
+	}
+}
+
+// TestAuditMiddleware_AffectedIDsIgnoreOutputFormat asserts that IDs taken
+// from a write tool's result are recorded even when the result text is
+// rendered in a format the audit trail cannot parse.
+func TestAuditMiddleware_AffectedIDsIgnoreOutputFormat(t *testing.T) {
+	t.Parallel()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/incident/create" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"incident_id":"inc-1"}}`))
+	}))
+	defer api.Close()
+
+	var trail bytes.Buffer
+	mcpServer, err := NewMCPServer(FlashdutyConfig{
+		Version:         "test",
+		BaseURL:         api.URL,
+		APPKey:          "secret-key",
+		Translator:      translations.NullTranslationHelper,
+		EnabledToolsets: []string{"all"},
+		Auditor:         audit.NewLogger(&trail, ""),
+	})
+	if err != nil {
+		t.Fatalf("failed to create MCP server: %v", err)
+	}
+
+	resp := mcpServer.HandleMessage(context.Background(), json.RawMessage(
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"create_incident","arguments":{"title":"db down","severity":"Critical","output_format":"csv"}}}`))
+	if result, ok := resp.(mcp.JSONRPCResponse); !ok || result.Result.(*mcp.CallToolResult).IsError {
+		t.Fatalf("create_incident failed: %+v", resp)
+	}
+
+	var entry audit.Entry
+	if err := json.Unmarshal(bytes.TrimSpace(trail.Bytes()), &entry); err != nil {
+		t.Fatalf("decode audit entry: %v (%s)", err, trail.String())
+	}
+	if got := strings.Join(entry.AffectedIDs["incident_id"], ","); got != "inc-1" {
+		t.Errorf("affected incident IDs = %q, want inc-1", got)
+	}
+}
@@ -2,7 +2,9 @@ package flashduty
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -46,6 +48,87 @@ var clientCache = gcache.New(1000).
 	Expiration(time.Hour).
 	Build()
 
+// pushTimeout bounds integration event pushes, matching the SDK's default
+// request timeout.
+const pushTimeout = 30 * time.Second
+
+// Defaults applied to zero-valued CacheConfig fields.
+const (
+	defaultCacheTTL  = 5 * time.Minute
+	defaultCacheSize = 1000
+)
+
+// CacheConfig controls the reference-data cache each APP key gets alongside
+// its client in clientCache.
+type CacheConfig struct {
+	// TTL is how long a cached lookup is served before it is fetched again.
+	// Defaults to 5m; negative disables the cache.
+	TTL time.Duration
+
+	// Size caps the number of cached lookups per APP key; the least recently
+	// used entry is evicted first. Defaults to 1000.
+	Size int
+}
+
+// referenceCache is an LRU cache with expiry for reference-data lookups
+// (member and team infos, channel and field lists) made with one APP key.
+// Being stored on the clients, it is scoped to an APP key and base URL and is
+// dropped when clientCache evicts them.
+type referenceCache struct {
+	cache gcache.Cache
+}
+
+// newReferenceCache returns nil when cfg disables caching.
+func newReferenceCache(cfg CacheConfig) *referenceCache {
+	if cfg.TTL < 0 {
+		return nil
+	}
+	if cfg.TTL == 0 {
+		cfg.TTL = defaultCacheTTL
+	}
+	if cfg.Size <= 0 {
+		cfg.Size = defaultCacheSize
+	}
+	return &referenceCache{cache: gcache.New(cfg.Size).LRU().Expiration(cfg.TTL).Build()}
+}
+
+// Get implements flashduty.ReferenceCache. Failed loads are not cached. Each
+// lookup is logged at debug level with the running hit and miss counts.
+func (c *referenceCache) Get(ctx context.Context, key string, refresh bool, load func() (any, error)) (any, error) {
+	if !refresh {
+		v, err := c.cache.Get(key)
+		if err == nil {
+			c.log(ctx, key, "hit")
+			return v, nil
+		}
+		if !errors.Is(err, gcache.KeyNotFoundError) {
+			return nil, err
+		}
+	}
+
+	v, err := load()
+	if err != nil {
+		return nil, err
+	}
+	_ = c.cache.Set(key, v)
+	if refresh {
+		c.log(ctx, key, "bypass")
+	} else {
+		c.log(ctx, key, "miss")
+	}
+	return v, nil
+}
+
+func (c *referenceCache) log(ctx context.Context, key, result string) {
+	slog.DebugContext(ctx, "reference cache lookup",
+		"key", key,
+		"result", result,
+		"hits", c.cache.HitCount(),
+		"misses", c.cache.MissCount(),
+		"entries", c.cache.Len(false),
+	)
+}
+
 // getClient is a helper for tool handlers to obtain the Flashduty clients. It
 // tries the context first; on a miss it builds the typed go-flashduty client,
 // caches it, and stores it on the context for reuse within the same request. It
@@ -79,9 +162,12 @@ func getClient(ctx context.Context, defaultCfg FlashdutyConfig, version string)
 		}
 	}
 
+	transport := newRetryTransport(&instrumentedTransport{base: http.DefaultTransport}, defaultCfg.Retry)
 	newOpts := []goflashduty.Option{
 		goflashduty.WithUserAgent(userAgent),
 		goflashduty.WithRequestHook(requestHook),
+		goflashduty.WithTransport(transport),
+		goflashduty.WithLogger(&sdkLogger{redactor: defaultCfg.Redactor}),
 	}
 	if cfg.BaseURL != "" {
 		newOpts = append(newOpts, goflashduty.WithBaseURL(cfg.BaseURL))
@@ -91,7 +177,13 @@ func getClient(ctx context.Context, defaultCfg FlashdutyConfig, version string)
 		return ctx, nil, fmt.Errorf("failed to create go-flashduty client: %w", err)
 	}
 
-	clients := &flashduty.Clients{New: newClient}
+	clients := &flashduty.Clients{
+		New:  newClient,
+		Push: &http.Client{Timeout: pushTimeout, Transport: transport},
+	}
+	if refCache := newReferenceCache(defaultCfg.Cache); refCache != nil {
+		clients.Cache = refCache
+	}
 
 	_ = clientCache.Set(cacheKey, clients)
 	ctx = contextWithClients(ctx, clients)
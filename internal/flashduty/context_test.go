@@ -0,0 +1,87 @@
+package flashduty
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReferenceCache(t *testing.T) {
+	t.Parallel()
+
+	c := newReferenceCache(CacheConfig{TTL: time.Minute, Size: 2})
+	ctx := context.Background()
+
+	loads := 0
+	load := func() (any, error) {
+		loads++
+		return loads, nil
+	}
+
+	get := func(key string, refresh bool) any {
+		t.Helper()
+		v, err := c.Get(ctx, key, refresh, load)
+		if err != nil {
+			t.Fatalf("Get(%q): %v", key, err)
+		}
+		return v
+	}
+
+	if v := get("a", false); v != 1 {
+		t.Fatalf("miss: got %v, want 1", v)
+	}
+	if v := get("a", false); v != 1 || loads != 1 {
+		t.Fatalf("hit: got %v after %d loads, want the cached 1", v, loads)
+	}
+	if v := get("a", true); v != 2 {
+		t.Fatalf("bypass: got %v, want a fresh 2", v)
+	}
+	if v := get("a", false); v != 2 {
+		t.Fatalf("bypass did not refresh the entry: got %v", v)
+	}
+
+	// Size caps the entries; the least recently used one goes first.
+	get("b", false)
+	get("c", false)
+	if c.cache.Has("a") {
+		t.Error("expected the least recently used entry to be evicted")
+	}
+
+	// Failed loads are returned but not cached.
+	boom := errors.New("boom")
+	if _, err := c.Get(ctx, "d", false, func() (any, error) { return nil, boom }); !errors.Is(err, boom) {
+		t.Fatalf("got %v, want the load error", err)
+	}
+	if c.cache.Has("d") {
+		t.Error("a failed load was cached")
+	}
+
+	if c.cache.HitCount() != 2 {
+		t.Errorf("hits = %d, want 2", c.cache.HitCount())
+	}
+}
+
+func TestReferenceCacheExpires(t *testing.T) {
+	t.Parallel()
+
+	c := newReferenceCache(CacheConfig{TTL: 10 * time.Millisecond})
+	loads := 0
+	load := func() (any, error) {
+		loads++
+		return loads, nil
+	}
+	_, _ = c.Get(context.Background(), "k", false, load)
+	time.Sleep(20 * time.Millisecond)
+	if v, _ := c.Get(context.Background(), "k", false, load); v != 2 {
+		t.Errorf("got %v after the TTL, want a reload", v)
+	}
+}
+
+func TestNewReferenceCacheDisabled(t *testing.T) {
+	t.Parallel()
+
+	if c := newReferenceCache(CacheConfig{TTL: -1}); c != nil {
+		t.Error("expected a negative TTL to disable the cache")
+	}
+}
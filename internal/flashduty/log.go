@@ -7,7 +7,13 @@ import (
 	"log/slog"
 	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	mcplog "github.com/flashcatcloud/flashduty-mcp-server/pkg/log"
 )
 
 // getLocalTimezone returns the local timezone location.
@@ -36,6 +42,181 @@ func getLocalTimezone() *time.Location {
 	return loc
 }
 
+// Log formats accepted by --log-format.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// newLogger builds the server logger. Records are written to logFilePath, or
+// stderr when it is empty, as ordered text or newline-delimited JSON. An empty
+// level keeps the historical defaults: debug for a log file, info on stderr.
+func newLogger(logFilePath, format, level string) (*slog.Logger, error) {
+	w, defaultLevel := io.Writer(os.Stderr), slog.LevelInfo
+	if logFilePath != "" {
+		// #nosec G304
+		file, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		w, defaultLevel = file, slog.LevelDebug
+	}
+
+	minLevel, err := parseLogLevel(level, defaultLevel)
+	if err != nil {
+		return nil, err
+	}
+	handler, err := newLogHandler(w, format, minLevel)
+	if err != nil {
+		return nil, err
+	}
+	return slog.New(newClientLogHandler(handler)), nil
+}
+
+// parseLogLevel parses debug, info, warn or error (case-insensitive), returning
+// def for an empty string.
+func parseLogLevel(s string, def slog.Level) (slog.Level, error) {
+	if s = strings.TrimSpace(s); s == "" {
+		return def, nil
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("invalid log level %q: expected debug, info, warn or error", s)
+	}
+	return level, nil
+}
+
+// newLogHandler returns the handler for format. JSON records carry the same
+// keys as the text format (time, level, trace_id, msg, tool, duration_ms, ...)
+// so either can feed the same log pipeline.
+func newLogHandler(w io.Writer, format string, level slog.Leveler) (slog.Handler, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", LogFormatText:
+		return newOrderedTextHandler(w, level), nil
+	case LogFormatJSON:
+		localTZ := getLocalTimezone()
+		return slog.NewJSONHandler(w, &slog.HandlerOptions{
+			Level: level,
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if len(groups) == 0 && a.Key == slog.TimeKey && a.Value.Kind() == slog.KindTime {
+					a.Value = slog.TimeValue(a.Value.Time().In(localTZ))
+				}
+				return a
+			},
+		}), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q: expected %s or %s", format, LogFormatText, LogFormatJSON)
+	}
+}
+
+// clientLogHandler forwards log records to the MCP client whose request
+// produced them, as notifications/message, at or above the level the client
+// chose with logging/setLevel (mcp-go defaults a session to error). Only
+// records logged with the request context (slog.InfoContext and friends) can
+// be attributed to a session.
+//
+// The wrapped handler still applies its own level, so a client asking for
+// debug raises what it receives without making the server's log verbose.
+type clientLogHandler struct {
+	base  slog.Handler
+	attrs []slog.Attr // Attributes added via WithAttrs
+}
+
+func newClientLogHandler(base slog.Handler) slog.Handler {
+	return &clientLogHandler{base: base}
+}
+
+// Enabled reports whether either the wrapped handler or the current client
+// wants records at level.
+func (h *clientLogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.base.Enabled(ctx, level) || clientWantsLog(ctx, level)
+}
+
+// Handle writes r to the wrapped handler and notifies the client. Failing to
+// notify is not an error: the client may have gone away mid-request.
+func (h *clientLogHandler) Handle(ctx context.Context, r slog.Record) error {
+	var err error
+	if h.base.Enabled(ctx, r.Level) {
+		err = h.base.Handle(ctx, r)
+	}
+	if clientWantsLog(ctx, r.Level) {
+		data := map[string]any{"msg": r.Message}
+		for _, a := range h.attrs {
+			data[a.Key] = logValue(a.Value)
+		}
+		r.Attrs(func(a slog.Attr) bool {
+			data[a.Key] = logValue(a.Value)
+			return true
+		})
+		notification := mcp.NewLoggingMessageNotification(mcpLoggingLevel(r.Level), "flashduty-mcp-server", data)
+		_ = server.ServerFromContext(ctx).SendLogMessageToClient(ctx, notification)
+	}
+	return err
+}
+
+// WithAttrs returns a new handler with the given attributes.
+func (h *clientLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	newAttrs := make([]slog.Attr, len(h.attrs)+len(attrs))
+	copy(newAttrs, h.attrs)
+	copy(newAttrs[len(h.attrs):], attrs)
+	return &clientLogHandler{base: h.base.WithAttrs(attrs), attrs: newAttrs}
+}
+
+// WithGroup returns a new handler with the given group name. Grouped
+// attributes are flattened in client notifications.
+func (h *clientLogHandler) WithGroup(name string) slog.Handler {
+	return &clientLogHandler{base: h.base.WithGroup(name), attrs: h.attrs}
+}
+
+// clientWantsLog reports whether ctx belongs to an initialized MCP session
+// whose log level admits level.
+func clientWantsLog(ctx context.Context, level slog.Level) bool {
+	if server.ServerFromContext(ctx) == nil {
+		return false
+	}
+	session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithLogging)
+	if !ok || !session.Initialized() {
+		return false
+	}
+	return mcpLoggingLevel(level).ShouldSendTo(session.GetLogLevel())
+}
+
+// mcpLoggingLevel maps a slog level to the nearest MCP (syslog) level.
+func mcpLoggingLevel(level slog.Level) mcp.LoggingLevel {
+	switch {
+	case level >= slog.LevelError:
+		return mcp.LoggingLevelError
+	case level >= slog.LevelWarn:
+		return mcp.LoggingLevelWarning
+	case level >= slog.LevelInfo:
+		return mcp.LoggingLevelInfo
+	default:
+		return mcp.LoggingLevelDebug
+	}
+}
+
+// logValue converts v to a JSON-friendly value for a client notification.
+func logValue(v slog.Value) any {
+	v = v.Resolve()
+	switch v.Kind() {
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return err.Error()
+		}
+		return v.Any()
+	case slog.KindGroup:
+		group := make(map[string]any, len(v.Group()))
+		for _, a := range v.Group() {
+			group[a.Key] = logValue(a.Value)
+		}
+		return group
+	case slog.KindDuration:
+		return v.Duration().String()
+	default:
+		return v.Any()
+	}
+}
+
 // orderedTextHandler is a custom slog handler that orders fields consistently:
 // time level trace_id msg [other fields...]
 type orderedTextHandler struct {
@@ -46,7 +227,7 @@ type orderedTextHandler struct {
 }
 
 // newOrderedTextHandler creates a new orderedTextHandler with local timezone support.
-func newOrderedTextHandler(w io.Writer, level slog.Level) slog.Handler {
+func newOrderedTextHandler(w io.Writer, level slog.Leveler) slog.Handler {
 	return &orderedTextHandler{
 		w:       w,
 		opts:    slog.HandlerOptions{Level: level},
@@ -228,3 +409,27 @@ func appendUint(buf []byte, x uint64) []byte {
 func appendFloat(buf []byte, x float64) []byte {
 	return strconv.AppendFloat(buf, x, 'g', -1, 64)
 }
+
+// sdkLogger routes go-flashduty's request/response logging to slog through the
+// server's Redactor. The SDK masks credentials itself but logs API bodies, and
+// those carry member emails and phone numbers.
+type sdkLogger struct {
+	redactor *mcplog.Redactor
+}
+
+func (l *sdkLogger) Debug(msg string, kv ...any) { slog.Debug(msg, l.redact(kv)...) }
+func (l *sdkLogger) Info(msg string, kv ...any)  { slog.Info(msg, l.redact(kv)...) }
+func (l *sdkLogger) Warn(msg string, kv ...any)  { slog.Warn(msg, l.redact(kv)...) }
+func (l *sdkLogger) Error(msg string, kv ...any) { slog.Error(msg, l.redact(kv)...) }
+
+// redact scrubs the string values of alternating key/value pairs.
+func (l *sdkLogger) redact(kv []any) []any {
+	out := make([]any, len(kv))
+	copy(out, kv)
+	for i := 1; i < len(out); i += 2 {
+		if s, ok := out[i].(string); ok {
+			out[i] = l.redactor.Redact(s)
+		}
+	}
+	return out
+}
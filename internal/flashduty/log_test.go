@@ -0,0 +1,144 @@
+package flashduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestNewLogHandler_JSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	handler, err := newLogHandler(&buf, "json", slog.LevelInfo)
+	if err != nil {
+		t.Fatalf("newLogHandler: %v", err)
+	}
+	logger := slog.New(handler)
+	logger.Debug("hidden")
+	logger.Info("mcp response", "trace_id", "abc", "tool", "query_incidents", "duration_ms", int64(42))
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected a single JSON line, got %q: %v", buf.String(), err)
+	}
+	for key, want := range map[string]any{
+		"level":       "INFO",
+		"msg":         "mcp response",
+		"trace_id":    "abc",
+		"tool":        "query_incidents",
+		"duration_ms": float64(42),
+	} {
+		if entry[key] != want {
+			t.Errorf("%s = %v, want %v", key, entry[key], want)
+		}
+	}
+	if _, ok := entry["time"]; !ok {
+		t.Error("missing time")
+	}
+
+	if _, err := newLogHandler(&buf, "xml", slog.LevelInfo); err == nil {
+		t.Error("expected an error for an unknown log format")
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in      string
+		want    slog.Level
+		wantErr bool
+	}{
+		{in: "", want: slog.LevelWarn},
+		{in: "debug", want: slog.LevelDebug},
+		{in: "INFO", want: slog.LevelInfo},
+		{in: "warn", want: slog.LevelWarn},
+		{in: " error ", want: slog.LevelError},
+		{in: "verbose", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseLogLevel(tt.in, slog.LevelWarn)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseLogLevel(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseLogLevel(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+// loggingSession is a minimal initialized session that supports logging/setLevel.
+type loggingSession struct {
+	notifications chan mcp.JSONRPCNotification
+	level         atomic.Value
+}
+
+func (s *loggingSession) SessionID() string                                   { return "test-session" }
+func (s *loggingSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s *loggingSession) Initialize()                                         {}
+func (s *loggingSession) Initialized() bool                                   { return true }
+func (s *loggingSession) SetLogLevel(level mcp.LoggingLevel)                  { s.level.Store(level) }
+func (s *loggingSession) GetLogLevel() mcp.LoggingLevel {
+	if level, ok := s.level.Load().(mcp.LoggingLevel); ok {
+		return level
+	}
+	return mcp.LoggingLevelError
+}
+
+func TestClientLogHandler_FollowsSessionLevel(t *testing.T) {
+	t.Parallel()
+
+	var serverLog bytes.Buffer
+	logger := slog.New(newClientLogHandler(newOrderedTextHandler(&serverLog, slog.LevelError)))
+
+	mcpServer := server.NewMCPServer("test", "test", server.WithLogging())
+	mcpServer.AddTool(mcp.NewTool("noisy"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		logger.DebugContext(ctx, "looking things up", "tool", "noisy")
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	session := &loggingSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	if err := mcpServer.RegisterSession(context.Background(), session); err != nil {
+		t.Fatalf("register session: %v", err)
+	}
+	ctx := mcpServer.WithContext(context.Background(), session)
+
+	callTool := `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"noisy"}}`
+	mcpServer.HandleMessage(ctx, json.RawMessage(callTool))
+	if len(session.notifications) != 0 {
+		t.Fatalf("debug record sent before the client asked for it")
+	}
+
+	resp := mcpServer.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"logging/setLevel","params":{"level":"debug"}}`))
+	if _, ok := resp.(mcp.JSONRPCResponse); !ok {
+		t.Fatalf("logging/setLevel failed: %+v", resp)
+	}
+	mcpServer.HandleMessage(ctx, json.RawMessage(callTool))
+
+	if len(session.notifications) != 1 {
+		t.Fatalf("got %d notifications, want 1", len(session.notifications))
+	}
+	n := <-session.notifications
+	if n.Method != string(mcp.MethodNotificationMessage) {
+		t.Errorf("method = %q", n.Method)
+	}
+	if level := n.Params.AdditionalFields["level"]; level != mcp.LoggingLevelDebug {
+		t.Errorf("level = %v, want debug", level)
+	}
+	data, _ := n.Params.AdditionalFields["data"].(map[string]any)
+	if data["msg"] != "looking things up" || data["tool"] != "noisy" {
+		t.Errorf("data = %v", data)
+	}
+
+	if serverLog.Len() != 0 {
+		t.Errorf("server log should stay at error level, got %q", serverLog.String())
+	}
+}
@@ -0,0 +1,192 @@
+package flashduty
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/trace"
+)
+
+const metricsNamespace = "flashduty_mcp"
+
+// metricsRegistry holds every collector served on /metrics. A dedicated
+// registry (rather than prometheus.DefaultRegisterer) keeps the exposition
+// limited to what this server records, whatever its dependencies register.
+var metricsRegistry = prometheus.NewRegistry()
+
+var (
+	toolCallsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "tool_calls_total",
+		Help:      "Number of MCP tool calls, by tool.",
+	}, []string{"tool"})
+
+	toolErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "tool_errors_total",
+		Help:      "Number of MCP tool calls that returned an error or an error result, by tool.",
+	}, []string{"tool"})
+
+	toolDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "tool_call_duration_seconds",
+		Help:      "Latency of MCP tool calls, by tool.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"tool"})
+
+	upstreamDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "upstream_request_duration_seconds",
+		Help:      "Latency of Flashduty Open API requests, by path and HTTP status code (\"error\" for transport failures).",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"path", "code"})
+
+	upstreamRetriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "upstream_retries_total",
+		Help:      "Number of retried Flashduty Open API requests, by path.",
+	}, []string{"path"})
+
+	activeSessions = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "active_sessions",
+		Help:      "Number of currently registered MCP sessions.",
+	})
+)
+
+func init() {
+	metricsRegistry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		toolCallsTotal,
+		toolErrorsTotal,
+		toolDuration,
+		upstreamDuration,
+		upstreamRetriesTotal,
+		activeSessions,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "client_cache_entries",
+			Help:      "Number of cached Flashduty clients.",
+		}, func() float64 { return float64(clientCache.Len(true)) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "client_cache_hits_total",
+			Help:      "Number of Flashduty client cache hits.",
+		}, func() float64 { return float64(clientCache.HitCount()) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "client_cache_misses_total",
+			Help:      "Number of Flashduty client cache misses.",
+		}, func() float64 { return float64(clientCache.MissCount()) }),
+	)
+}
+
+// toolMetricsMiddleware records call count, error count and latency for every
+// tool call. A result with IsError set counts as an error: handlers report most
+// failures that way rather than through the Go error.
+func toolMetricsMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := time.Now()
+		result, err := next(ctx, request)
+
+		tool := request.Params.Name
+		toolCallsTotal.WithLabelValues(tool).Inc()
+		toolDuration.WithLabelValues(tool).Observe(time.Since(start).Seconds())
+		if err != nil || (result != nil && result.IsError) {
+			toolErrorsTotal.WithLabelValues(tool).Inc()
+		}
+		return result, err
+	}
+}
+
+// sessionMetricsHooks keeps the active session gauge in step with the
+// sessions mcp-go registers and unregisters.
+func sessionMetricsHooks(hooks *server.Hooks) {
+	hooks.AddOnRegisterSession(func(_ context.Context, _ server.ClientSession) {
+		activeSessions.Inc()
+	})
+	hooks.AddOnUnregisterSession(func(_ context.Context, _ server.ClientSession) {
+		activeSessions.Dec()
+	})
+}
+
+// instrumentedTransport records the latency and status of each request the
+// go-flashduty client sends, and wraps it in a client span whose ID replaces
+// the traceparent set by the request hook, so the backend sees this call as
+// its parent. It is installed with goflashduty.WithTransport, the SDK's seam
+// for transport middleware.
+type instrumentedTransport struct {
+	base http.RoundTripper
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := trace.StartSpan(req.Context(), "flashduty "+req.URL.Path,
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(
+			attribute.String("mcp.tool.name", toolNameFromContext(req.Context())),
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.path", req.URL.Path),
+			attribute.Int64("http.request.body.size", req.ContentLength),
+		),
+	)
+	defer span.End()
+	if span.IsRecording() {
+		req = req.Clone(ctx)
+		trace.FromContext(ctx).SetHTTPHeaders(req.Header)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= 400 {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	} else {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	upstreamDuration.WithLabelValues(req.URL.Path, code).Observe(time.Since(start).Seconds())
+	return resp, err
+}
+
+// healthzHandler reports liveness: the process is up and serving HTTP.
+func healthzHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// readyzHandler reports readiness. It turns 503 once shutdown has begun so a
+// load balancer drains traffic while in-flight requests finish.
+func readyzHandler(shuttingDown *atomic.Bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if shuttingDown.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("shutting down\n"))
+			return
+		}
+		_, _ = w.Write([]byte("ok\n"))
+	}
+}
+
+// metricsHandler serves the Prometheus exposition for metricsRegistry.
+func metricsHandler() http.Handler {
+	return promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{})
+}
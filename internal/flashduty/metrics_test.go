@@ -0,0 +1,115 @@
+package flashduty
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestReadyzReportsShutdown(t *testing.T) {
+	t.Parallel()
+
+	var shuttingDown atomic.Bool
+	handler := readyzHandler(&shuttingDown)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d before shutdown, want 200", rec.Code)
+	}
+
+	shuttingDown.Store(true)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d during shutdown, want 503", rec.Code)
+	}
+}
+
+func TestDrainAndShutdownKeepsServingReadyz(t *testing.T) {
+	t.Parallel()
+
+	var shuttingDown atomic.Bool
+	mux := http.NewServeMux()
+	mux.Handle("/readyz", readyzHandler(&shuttingDown))
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: time.Second}
+	go func() { _ = srv.Serve(ln) }()
+	url := "http://" + ln.Addr().String() + "/readyz"
+
+	done := make(chan error, 1)
+	go func() {
+		done <- drainAndShutdown(srv, &shuttingDown, 500*time.Millisecond, slog.New(slog.DiscardHandler))
+	}()
+
+	// Between the signal and the close, probes must see 503 rather than a
+	// refused connection.
+	deadline := time.Now().Add(400 * time.Millisecond)
+	for !shuttingDown.Load() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("readyz during drain: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d during drain, want 503", resp.StatusCode)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("drainAndShutdown: %v", err)
+	}
+	if resp, err := http.Get(url); err == nil {
+		_ = resp.Body.Close()
+		t.Fatal("server still accepting connections after shutdown")
+	}
+}
+
+func TestToolMetricsMiddlewareCountsErrors(t *testing.T) {
+	t.Parallel()
+
+	const tool = "metrics_test_tool"
+	ok := toolMetricsMiddleware(func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	errResult := toolMetricsMiddleware(func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultError("bad input"), nil
+	})
+	goErr := toolMetricsMiddleware(func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, errors.New("boom")
+	})
+
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Name: tool}}
+	for _, h := range []func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error){ok, errResult, goErr} {
+		_, _ = h(context.Background(), req)
+	}
+
+	if got := testutil.ToFloat64(toolCallsTotal.WithLabelValues(tool)); got != 3 {
+		t.Errorf("tool_calls_total = %v, want 3", got)
+	}
+	if got := testutil.ToFloat64(toolErrorsTotal.WithLabelValues(tool)); got != 2 {
+		t.Errorf("tool_errors_total = %v, want 2", got)
+	}
+
+	rec := httptest.NewRecorder()
+	metricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, name := range []string{"flashduty_mcp_tool_call_duration_seconds", "flashduty_mcp_client_cache_entries", "flashduty_mcp_active_sessions"} {
+		if !strings.Contains(rec.Body.String(), name) {
+			t.Errorf("/metrics output missing %s", name)
+		}
+	}
+}
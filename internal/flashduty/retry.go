@@ -0,0 +1,237 @@
+package flashduty
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const idempotencyKeyKey = contextKey("idempotencyKey")
+
+// idempotencyKeyHeader carries a write's idempotency key to the Open API.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// Defaults applied to zero-valued RetryConfig fields.
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryBudget      = 20 * time.Second
+	retryBaseDelay          = 500 * time.Millisecond
+	retryMaxDelay           = 5 * time.Second
+)
+
+// RetryConfig controls how Flashduty API calls are retried on 429, 502, 503,
+// 504 and transport errors.
+type RetryConfig struct {
+	// MaxAttempts is the total number of tries per API call, including the
+	// first. Defaults to 3; 1 disables retries.
+	MaxAttempts int
+
+	// Budget caps the time spent on one API call across all attempts and
+	// waits. Defaults to 20s.
+	Budget time.Duration
+
+	// RetryWrites also retries write endpoints, but only calls that carry an
+	// idempotency key (see idempotencyKeyMiddleware). Reads are always
+	// retried.
+	RetryWrites bool
+}
+
+// retryTransport retries transient Open API failures with exponential backoff
+// and full jitter, waiting for Retry-After instead when the server sends one.
+// It sits outside instrumentedTransport so every attempt gets its own span and
+// latency sample.
+//
+// The Open API is POST-only, so whether a call is safe to repeat is decided by
+// its path (see isReadPath). Writes are retried only when RetryWrites is set
+// and the call carries an idempotency key, which is forwarded to the API as the
+// Idempotency-Key header.
+type retryTransport struct {
+	base http.RoundTripper
+	cfg  RetryConfig
+}
+
+func newRetryTransport(base http.RoundTripper, cfg RetryConfig) *retryTransport {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultRetryMaxAttempts
+	}
+	if cfg.Budget <= 0 {
+		cfg.Budget = defaultRetryBudget
+	}
+	return &retryTransport{base: base, cfg: cfg}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if key := idempotencyKeyFromContext(ctx); key != "" {
+		req = req.Clone(ctx)
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
+	if !t.retryable(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	deadline := time.Now().Add(t.cfg.Budget)
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 {
+			attemptReq = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if attempt >= t.cfg.MaxAttempts || !shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		wait := retryAfter(resp)
+		if wait <= 0 {
+			wait = backoff(attempt)
+		}
+		if time.Now().Add(wait).After(deadline) {
+			return resp, err
+		}
+
+		attrs := []any{"path", req.URL.Path, "attempt", attempt, "wait_ms", wait.Milliseconds()}
+		if err != nil {
+			attrs = append(attrs, "error", err)
+		} else {
+			attrs = append(attrs, "status", resp.StatusCode)
+			drainAndClose(resp.Body)
+		}
+		slog.WarnContext(ctx, "retrying Flashduty API request", attrs...)
+		upstreamRetriesTotal.WithLabelValues(req.URL.Path).Inc()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable reports whether req may be sent more than once: its body can be
+// replayed, and it is a read or an opted-in write with an idempotency key.
+func (t *retryTransport) retryable(req *http.Request) bool {
+	if t.cfg.MaxAttempts <= 1 || (req.Body != nil && req.GetBody == nil) {
+		return false
+	}
+	if req.Method == http.MethodGet || isReadPath(req.URL.Path) {
+		return true
+	}
+	return t.cfg.RetryWrites && req.Header.Get(idempotencyKeyHeader) != ""
+}
+
+// readPathSuffixes are the final path segments of Open API endpoints that only
+// read data. Anything else is treated as a write.
+var readPathSuffixes = map[string]struct{}{
+	"list":          {},
+	"list-by-ids":   {},
+	"info":          {},
+	"infos":         {},
+	"get":           {},
+	"detail":        {},
+	"feed":          {},
+	"search":        {},
+	"query":         {},
+	"count":         {},
+	"total":         {},
+	"topk-by-label": {},
+}
+
+// isReadPath reports whether the Open API endpoint at path is a read.
+// Analytics (/insight/...) endpoints only ever read.
+func isReadPath(path string) bool {
+	if strings.HasPrefix(path, "/insight/") {
+		return true
+	}
+	_, ok := readPathSuffixes[path[strings.LastIndexByte(path, '/')+1:]]
+	return ok
+}
+
+// shouldRetry reports whether a response or transport error is transient.
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter returns the wait requested by a Retry-After header, given as
+// delta-seconds or an HTTP date, or 0 when there is none.
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	v := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
+
+// backoff returns a random wait in [0, min(retryMaxDelay, retryBaseDelay *
+// 2^(attempt-1))), the "full jitter" strategy, so concurrent callers hitting
+// the same 429 do not retry in lockstep.
+func backoff(attempt int) time.Duration {
+	ceiling := retryMaxDelay
+	if attempt < 8 {
+		ceiling = min(retryMaxDelay, retryBaseDelay<<(attempt-1))
+	}
+	return rand.N(ceiling)
+}
+
+// drainAndClose discards a bounded amount of a response body that will not be
+// read, so the connection can be reused.
+func drainAndClose(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, 1<<20))
+	_ = body.Close()
+}
+
+// idempotencyKeyFromContext returns the idempotency key of the current tool
+// call, if the client supplied one.
+func idempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyKey).(string)
+	return key
+}
+
+// idempotencyKeyMiddleware lifts an idempotency key from the tool call's
+// `_meta.idempotency_key` onto the context, where retryTransport finds it.
+// Clients set one per logical write so that a retried write is applied once.
+func idempotencyKeyMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if meta := request.Params.Meta; meta != nil {
+			if key, ok := meta.AdditionalFields["idempotency_key"].(string); ok && strings.TrimSpace(key) != "" {
+				ctx = context.WithValue(ctx, idempotencyKeyKey, strings.TrimSpace(key))
+			}
+		}
+		return next(ctx, request)
+	}
+}
@@ -0,0 +1,210 @@
+package flashduty
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer fails the first `failures` requests with status, then succeeds.
+// It records the request count and the last body and idempotency key seen.
+type flakyServer struct {
+	failures int32
+	status   int
+	header   http.Header
+
+	calls    atomic.Int32
+	lastBody atomic.Value
+	lastKey  atomic.Value
+}
+
+func (s *flakyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	s.lastBody.Store(string(body))
+	s.lastKey.Store(r.Header.Get(idempotencyKeyHeader))
+	if s.calls.Add(1) <= s.failures {
+		for k, v := range s.header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(s.status)
+		return
+	}
+	_, _ = w.Write([]byte(`{"data":{}}`))
+}
+
+func postJSON(t *testing.T, ctx context.Context, rt http.RoundTripper, url string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(`{"p":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	_ = resp.Body.Close()
+	return resp
+}
+
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		path      string
+		cfg       RetryConfig
+		key       string
+		server    *flakyServer
+		wantCalls int32
+		wantCode  int
+	}{
+		{
+			name:      "read retried until success",
+			path:      "/incident/list",
+			server:    &flakyServer{failures: 2, status: http.StatusBadGateway},
+			wantCalls: 3,
+			wantCode:  http.StatusOK,
+		},
+		{
+			name:      "read gives up after max attempts",
+			path:      "/channel/infos",
+			cfg:       RetryConfig{MaxAttempts: 2},
+			server:    &flakyServer{failures: 5, status: http.StatusServiceUnavailable},
+			wantCalls: 2,
+			wantCode:  http.StatusServiceUnavailable,
+		},
+		{
+			name:      "client errors are not retried",
+			path:      "/incident/list",
+			server:    &flakyServer{failures: 1, status: http.StatusBadRequest},
+			wantCalls: 1,
+			wantCode:  http.StatusBadRequest,
+		},
+		{
+			name:      "Retry-After beyond the budget returns the 429",
+			path:      "/incident/list",
+			cfg:       RetryConfig{Budget: time.Second},
+			server:    &flakyServer{failures: 1, status: http.StatusTooManyRequests, header: http.Header{"Retry-After": {"60"}}},
+			wantCalls: 1,
+			wantCode:  http.StatusTooManyRequests,
+		},
+		{
+			name:      "write not retried by default",
+			path:      "/incident/ack",
+			key:       "k-1",
+			server:    &flakyServer{failures: 1, status: http.StatusBadGateway},
+			wantCalls: 1,
+			wantCode:  http.StatusBadGateway,
+		},
+		{
+			name:      "write without idempotency key not retried",
+			path:      "/incident/ack",
+			cfg:       RetryConfig{RetryWrites: true},
+			server:    &flakyServer{failures: 1, status: http.StatusBadGateway},
+			wantCalls: 1,
+			wantCode:  http.StatusBadGateway,
+		},
+		{
+			name:      "opted-in write with idempotency key retried",
+			path:      "/incident/ack",
+			cfg:       RetryConfig{RetryWrites: true},
+			key:       "k-1",
+			server:    &flakyServer{failures: 1, status: http.StatusBadGateway},
+			wantCalls: 2,
+			wantCode:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ts := httptest.NewServer(tt.server)
+			defer ts.Close()
+
+			ctx := context.Background()
+			if tt.key != "" {
+				ctx = context.WithValue(ctx, idempotencyKeyKey, tt.key)
+			}
+			resp := postJSON(t, ctx, newRetryTransport(http.DefaultTransport, tt.cfg), ts.URL+tt.path)
+
+			if got := tt.server.calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantCode)
+			}
+			if body := tt.server.lastBody.Load(); body != `{"p":1}` {
+				t.Errorf("last attempt body = %q, want the original body replayed", body)
+			}
+			if key := tt.server.lastKey.Load(); key != tt.key {
+				t.Errorf("Idempotency-Key = %q, want %q", key, tt.key)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	header := func(v string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": {v}}}
+	}
+	if got := retryAfter(header("3")); got != 3*time.Second {
+		t.Errorf("delta-seconds: got %v", got)
+	}
+	date := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	if got := retryAfter(header(date)); got < 8*time.Second || got > 10*time.Second {
+		t.Errorf("HTTP date: got %v", got)
+	}
+	if got := retryAfter(header("soon")); got != 0 {
+		t.Errorf("invalid: got %v", got)
+	}
+	if got := retryAfter(nil); got != 0 {
+		t.Errorf("nil response: got %v", got)
+	}
+}
+
+func TestBackoffIsBoundedAndJittered(t *testing.T) {
+	t.Parallel()
+
+	seen := map[time.Duration]bool{}
+	for i := 0; i < 50; i++ {
+		for attempt := 1; attempt <= 10; attempt++ {
+			d := backoff(attempt)
+			if d < 0 || d >= retryMaxDelay {
+				t.Fatalf("backoff(%d) = %v out of range", attempt, d)
+			}
+			if attempt == 1 && d >= retryBaseDelay {
+				t.Fatalf("backoff(1) = %v, want < %v", d, retryBaseDelay)
+			}
+			seen[d] = true
+		}
+	}
+	if len(seen) < 10 {
+		t.Errorf("backoff looks deterministic: %d distinct waits", len(seen))
+	}
+}
+
+func TestIsReadPath(t *testing.T) {
+	t.Parallel()
+
+	for path, want := range map[string]bool{
+		"/incident/list":             true,
+		"/person/infos":              true,
+		"/insight/incident/list":     true,
+		"/alert/topk-by-label":       true,
+		"/incident/create":           false,
+		"/incident/ack":              false,
+		"/status-page/change/create": false,
+	} {
+		if got := isReadPath(path); got != want {
+			t.Errorf("isReadPath(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -10,16 +10,21 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/audit"
 	pkgerrors "github.com/flashcatcloud/flashduty-mcp-server/pkg/errors"
 	"github.com/flashcatcloud/flashduty-mcp-server/pkg/flashduty"
 	mcplog "github.com/flashcatcloud/flashduty-mcp-server/pkg/log"
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/toolsets"
 	"github.com/flashcatcloud/flashduty-mcp-server/pkg/trace"
 	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
 )
@@ -42,6 +47,24 @@ type FlashdutyConfig struct {
 
 	// Translator provides translated text for the server tooling
 	Translator translations.TranslationHelperFunc
+
+	// Redactor scrubs secrets and PII from logged requests and responses.
+	// Defaults to the built-in rules when nil.
+	Redactor *mcplog.Redactor
+
+	// Auditor records every write tool call. Auditing is off when nil.
+	Auditor *audit.Logger
+
+	// Retry controls retries of failed Flashduty API calls.
+	Retry RetryConfig
+
+	// Cache controls the per-APP-key cache of reference data.
+	Cache CacheConfig
+
+	// OutputFormat is the serialization of tool results. On the server
+	// config it is the default; on a per-request config (HTTP) it is the
+	// client's choice and empty when the client made none.
+	OutputFormat flashduty.OutputFormat
 }
 
 // serverInstructions is the default text returned to clients in the
@@ -74,6 +97,9 @@ func NewMCPServer(cfg FlashdutyConfig) (*server.MCPServer, error) {
 	if len(cfg.EnabledToolsets) == 0 {
 		cfg.EnabledToolsets = []string{"all"}
 	}
+	if cfg.Redactor == nil {
+		cfg.Redactor = mcplog.DefaultRedactor()
+	}
 
 	toJSONString := func(v any) string {
 		data, err := json.Marshal(v)
@@ -83,15 +109,33 @@ func NewMCPServer(cfg FlashdutyConfig) (*server.MCPServer, error) {
 		return string(data)
 	}
 
-	buildLogAttrs := func(ctx context.Context, id any, method mcp.MCPMethod, extraAttrs ...any) []any {
+	// started holds the start time of each in-flight request, keyed by the
+	// request message: mcp-go hands the same pointer to the before hook and to
+	// the success or error hook, so the latter can report duration_ms.
+	var started sync.Map
+
+	buildLogAttrs := func(ctx context.Context, id any, method mcp.MCPMethod, message any, extraAttrs ...any) []any {
 		attrs := []any{}
 		if tc := trace.FromContext(ctx); tc != nil {
 			attrs = append(attrs, "trace_id", tc.TraceID)
 		}
 		attrs = append(attrs, "id", id, "method", method)
+		if req, ok := message.(*mcp.CallToolRequest); ok {
+			attrs = append(attrs, "tool", req.Params.Name)
+		}
 		return append(attrs, extraAttrs...)
 	}
 
+	withDuration := func(message any, attrs []any) []any {
+		if !isPointer(message) {
+			return attrs
+		}
+		if start, ok := started.LoadAndDelete(message); ok {
+			attrs = append(attrs, "duration_ms", time.Since(start.(time.Time)).Milliseconds())
+		}
+		return attrs
+	}
+
 	hooks := &server.Hooks{
 		OnBeforeInitialize: []server.OnBeforeInitializeFunc{beforeInit},
 		OnBeforeAny: []server.BeforeAnyHookFunc{
@@ -99,25 +143,36 @@ func NewMCPServer(cfg FlashdutyConfig) (*server.MCPServer, error) {
 				pkgerrors.ContextWithFlashdutyErrors(ctx)
 			},
 			func(ctx context.Context, id any, method mcp.MCPMethod, message any) {
-				attrs := buildLogAttrs(ctx, id, method, "params", mcplog.TruncateBodyDefault(toJSONString(message)))
-				slog.Info("mcp request", attrs...)
+				if isPointer(message) {
+					started.Store(message, time.Now())
+				}
+				attrs := buildLogAttrs(ctx, id, method, message, "params", mcplog.TruncateBodyDefault(cfg.Redactor.Redact(toJSONString(message))))
+				slog.InfoContext(ctx, "mcp request", attrs...)
 			},
 		},
 		OnSuccess: []server.OnSuccessHookFunc{
 			func(ctx context.Context, id any, method mcp.MCPMethod, message any, result any) {
-				attrs := buildLogAttrs(ctx, id, method, "result", mcplog.TruncateBodyDefault(toJSONString(result)))
-				slog.Info("mcp response", attrs...)
+				attrs := buildLogAttrs(ctx, id, method, message, "result", mcplog.TruncateBodyDefault(cfg.Redactor.Redact(toJSONString(result))))
+				slog.InfoContext(ctx, "mcp response", withDuration(message, attrs)...)
 			},
 		},
 		OnError: []server.OnErrorHookFunc{
 			func(ctx context.Context, id any, method mcp.MCPMethod, message any, err error) {
-				attrs := buildLogAttrs(ctx, id, method, "error", err)
-				slog.Error("mcp error", attrs...)
+				attrs := buildLogAttrs(ctx, id, method, message, "error", cfg.Redactor.Redact(err.Error()))
+				// A blocked notification channel is reported through this
+				// hook; forwarding that error to the same client as another
+				// notification would only block again.
+				logCtx := ctx
+				if method == "notification" {
+					logCtx = context.Background()
+				}
+				slog.ErrorContext(logCtx, "mcp error", withDuration(message, attrs)...)
 			},
 		},
 	}
 
-	flashdutyServer := server.NewMCPServer("flashduty-mcp-server", cfg.Version, server.WithHooks(hooks), server.WithInstructions(cfg.Translator("SERVER_INSTRUCTIONS", serverInstructions)))
+	sessionMetricsHooks(hooks)
+	requestSpanHooks(hooks)
 
 	getClientFn := func(ctx context.Context) (context.Context, *flashduty.Clients, error) {
 		return getClient(ctx, cfg, cfg.Version)
@@ -130,12 +185,80 @@ func NewMCPServer(cfg FlashdutyConfig) (*server.MCPServer, error) {
 		return nil, fmt.Errorf("failed to enable toolsets: %w", err)
 	}
 
+	opts := []server.ServerOption{
+		server.WithHooks(hooks),
+		server.WithToolFilter(sessionToolFilter(tsg)),
+		server.WithToolHandlerMiddleware(toolTracingMiddleware),
+		server.WithToolHandlerMiddleware(toolMetricsMiddleware),
+		server.WithToolHandlerMiddleware(idempotencyKeyMiddleware),
+		server.WithToolHandlerMiddleware(outputFormatMiddleware(cfg.OutputFormat)),
+		server.WithInstructions(cfg.Translator("SERVER_INSTRUCTIONS", serverInstructions)),
+		server.WithLogging(),
+	}
+	if cfg.Auditor != nil {
+		opts = append(opts, server.WithToolHandlerMiddleware(auditMiddleware(cfg)))
+	}
+
+	flashdutyServer := server.NewMCPServer("flashduty-mcp-server", cfg.Version, opts...)
+
 	// Register all mcp functionality with the server
 	tsg.RegisterAll(flashdutyServer)
 
 	return flashdutyServer, nil
 }
 
+// sessionToolFilter narrows the registered tools to what the session's config
+// allows. The HTTP server registers every toolset once and relies on this to
+// honor each caller's ?toolsets= and ?read_only= parameters; mcp-go applies the
+// filter to both tools/list and tools/call, so a hidden write tool cannot be
+// invoked by name either. Sessions without a config on the context (stdio) were
+// already restricted at registration time and pass through unchanged.
+func sessionToolFilter(tsg *toolsets.ToolsetGroup) server.ToolFilterFunc {
+	return func(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+		cfg, ok := ConfigFromContext(ctx)
+		if !ok {
+			return tools
+		}
+		enabledToolsets := cfg.EnabledToolsets
+		if len(enabledToolsets) == 0 {
+			enabledToolsets = []string{"all"}
+		}
+		filtered := make([]mcp.Tool, 0, len(tools))
+		for _, tool := range tools {
+			if tsg.IsToolAllowed(tool.Name, enabledToolsets, cfg.ReadOnly) {
+				filtered = append(filtered, tool)
+			}
+		}
+		return filtered
+	}
+}
+
+// outputFormatMiddleware puts the session's output format on the context,
+// where MarshalResult reads it: the format an HTTP client chose with
+// ?output_format= or the X-Output-Format header, else defaultFormat. A tool's
+// own `output_format` argument is applied later and takes precedence.
+func outputFormatMiddleware(defaultFormat flashduty.OutputFormat) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			format := defaultFormat
+			if cfg, ok := ConfigFromContext(ctx); ok && cfg.OutputFormat != "" {
+				format = cfg.OutputFormat
+			}
+			if format != "" {
+				ctx = flashduty.ContextWithOutputFormat(ctx, format)
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// isPointer reports whether v is a non-nil pointer, and so usable as a map key
+// that identifies one request.
+func isPointer(v any) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Pointer && !rv.IsNil()
+}
+
 func newStreamableHTTPServer(mcpServer *server.MCPServer, logger *slog.Logger, contextFunc server.HTTPContextFunc) *server.StreamableHTTPServer {
 	return server.NewStreamableHTTPServer(
 		mcpServer,
@@ -167,7 +290,7 @@ type StdioServerConfig struct {
 	// ReadOnly indicates if we should only register read-only tools
 	ReadOnly bool
 
-	// OutputFormat specifies the format for tool results (json or toon)
+	// OutputFormat specifies the format for tool results (json, toon, markdown or csv)
 	OutputFormat string
 
 	// ExportTranslations indicates if we should export translations
@@ -178,6 +301,50 @@ type StdioServerConfig struct {
 
 	// Path to the log file if not stderr
 	LogFilePath string
+
+	// LogFormat is "text" (default) or "json"
+	LogFormat string
+
+	// LogLevel is the minimum level logged (debug, info, warn or error).
+	// Defaults to debug for a log file and info on stderr.
+	LogLevel string
+
+	// OTLPEndpoint is the OTLP/HTTP collector URL spans are exported to.
+	// Tracing export is disabled when empty.
+	OTLPEndpoint string
+
+	// Redaction adds user-defined rules to the built-in log redaction.
+	Redaction mcplog.RedactionConfig
+
+	// AuditLog is where write tool calls are recorded: a JSONL file path or
+	// "syslog". Auditing is off when empty.
+	AuditLog string
+
+	// Retry controls retries of failed Flashduty API calls.
+	Retry RetryConfig
+
+	// Cache controls the per-APP-key cache of reference data.
+	Cache CacheConfig
+}
+
+// setupTracing installs the OTLP span exporter when endpoint is set. The
+// returned function flushes pending spans and is safe to call when export is
+// disabled.
+func setupTracing(ctx context.Context, endpoint, version string) (func(), error) {
+	if endpoint == "" {
+		return func() {}, nil
+	}
+	shutdown, err := trace.SetupOTLPExporter(ctx, endpoint, "flashduty-mcp-server", version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up tracing: %w", err)
+	}
+	return func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdown(shutdownCtx); err != nil {
+			slog.Warn("Failed to flush trace spans", "error", err)
+		}
+	}, nil
 }
 
 // RunStdioServer is not concurrent safe.
@@ -186,8 +353,31 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	// Set the global output format
-	flashduty.SetOutputFormat(flashduty.ParseOutputFormat(cfg.OutputFormat))
+	// Setup slog logger, also as the default so the MCP hooks log through it
+	logger, err := newLogger(cfg.LogFilePath, cfg.LogFormat, cfg.LogLevel)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := setupTracing(ctx, cfg.OTLPEndpoint, cfg.Version)
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing()
+
+	redactor, err := mcplog.NewRedactor(cfg.Redaction)
+	if err != nil {
+		return fmt.Errorf("failed to configure log redaction: %w", err)
+	}
+
+	auditor, err := newAuditLogger(cfg.AuditLog)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if auditor != nil {
+		defer func() { _ = auditor.Close() }()
+	}
 
 	t, dumpTranslations := translations.TranslationHelper()
 
@@ -198,6 +388,11 @@ func RunStdioServer(cfg StdioServerConfig) error {
 		EnabledToolsets: cfg.EnabledToolsets,
 		ReadOnly:        cfg.ReadOnly,
 		Translator:      t,
+		Redactor:        redactor,
+		Auditor:         auditor,
+		Retry:           cfg.Retry,
+		Cache:           cfg.Cache,
+		OutputFormat:    flashduty.ParseOutputFormat(cfg.OutputFormat),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
@@ -210,26 +405,13 @@ func RunStdioServer(cfg StdioServerConfig) error {
 
 	stdioServer := server.NewStdioServer(flashdutyServer)
 
-	// Setup slog logger
-	var slogHandler slog.Handler
-	if cfg.LogFilePath != "" {
-		file, err := os.OpenFile(cfg.LogFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
-		if err != nil {
-			return fmt.Errorf("failed to open log file: %w", err)
-		}
-		slogHandler = newOrderedTextHandler(file, slog.LevelDebug)
-	} else {
-		slogHandler = newOrderedTextHandler(os.Stderr, slog.LevelInfo)
-	}
-	logger := slog.New(slogHandler)
-
 	// Start listening for messages
 	errC := make(chan error, 1)
 	go func() {
 		in, out := io.Reader(os.Stdin), io.Writer(os.Stdout)
 
 		if cfg.EnableCommandLogging {
-			loggedIO := mcplog.NewIOLogger(in, out, logger)
+			loggedIO := mcplog.NewIOLogger(in, out, logger).WithRedactor(redactor)
 			in, out = loggedIO, loggedIO
 		}
 		// enable Flashduty errors in the context
@@ -264,14 +446,49 @@ type HTTPServerConfig struct {
 	// Flashduty API Base URL
 	BaseURL string
 
+	// AllowedBaseURLs lists the origins a caller may select with `?base_url=`,
+	// in addition to BaseURL itself. Entries may use a "*." host prefix to
+	// allow any subdomain, e.g. "https://*.flashcat.cloud".
+	AllowedBaseURLs []string
+
 	// Port to listen on
 	Port string
 
-	// OutputFormat specifies the format for tool results (json or toon)
+	// ShutdownDrain is how long the server keeps serving after a shutdown
+	// signal, with /readyz answering 503, before it stops accepting
+	// connections. Set it longer than the readiness probe period so load
+	// balancers notice before the listener closes.
+	ShutdownDrain time.Duration
+
+	// OutputFormat specifies the format for tool results (json, toon, markdown or csv)
 	OutputFormat string
 
 	// Path to the log file if not stderr
 	LogFilePath string
+
+	// LogFormat is "text" (default) or "json"
+	LogFormat string
+
+	// LogLevel is the minimum level logged (debug, info, warn or error).
+	// Defaults to debug for a log file and info on stderr.
+	LogLevel string
+
+	// OTLPEndpoint is the OTLP/HTTP collector URL spans are exported to.
+	// Tracing export is disabled when empty.
+	OTLPEndpoint string
+
+	// Redaction adds user-defined rules to the built-in log redaction.
+	Redaction mcplog.RedactionConfig
+
+	// AuditLog is where write tool calls are recorded: a JSONL file path or
+	// "syslog". Auditing is off when empty.
+	AuditLog string
+
+	// Retry controls retries of failed Flashduty API calls.
+	Retry RetryConfig
+
+	// Cache controls the per-APP-key cache of reference data.
+	Cache CacheConfig
 }
 
 // extractAppKey extracts app_key from Authorization header or query parameters
@@ -285,13 +502,27 @@ func extractAppKey(r *http.Request) string {
 	return r.URL.Query().Get("app_key")
 }
 
+// extractOutputFormat returns the output format the client asked for with the
+// output_format query parameter or the X-Output-Format header, preferring the
+// query parameter.
+func extractOutputFormat(r *http.Request) string {
+	if format := r.URL.Query().Get("output_format"); format != "" {
+		return format
+	}
+	return r.Header.Get("X-Output-Format")
+}
+
 // httpContextFunc extracts configuration from the HTTP request and injects it into the context.
 func httpContextFunc(ctx context.Context, r *http.Request, defaultBaseURL string) context.Context {
 	queryParams := r.URL.Query()
 
 	var enabledToolsets []string
 	if toolsets := queryParams.Get("toolsets"); toolsets != "" {
-		enabledToolsets = strings.Split(toolsets, ",")
+		for _, name := range strings.Split(toolsets, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				enabledToolsets = append(enabledToolsets, name)
+			}
+		}
 	}
 
 	baseURL := queryParams.Get("base_url")
@@ -305,30 +536,46 @@ func httpContextFunc(ctx context.Context, r *http.Request, defaultBaseURL string
 		EnabledToolsets: enabledToolsets,
 		ReadOnly:        queryParams.Get("read_only") == "true",
 	}
+	if format := extractOutputFormat(r); format != "" {
+		cfg.OutputFormat = flashduty.ParseOutputFormat(format)
+	}
 
 	return ContextWithConfig(ctx, cfg)
 }
 
 func RunHTTPServer(cfg HTTPServerConfig) error {
-	// Set the global output format
-	flashduty.SetOutputFormat(flashduty.ParseOutputFormat(cfg.OutputFormat))
-
 	// Setup slog logger
-	var slogHandler slog.Handler
-	if cfg.LogFilePath != "" {
-		// #nosec G304
-		file, err := os.OpenFile(cfg.LogFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
-		if err != nil {
-			return fmt.Errorf("failed to open log file: %w", err)
-		}
-		slogHandler = newOrderedTextHandler(file, slog.LevelDebug)
-	} else {
-		slogHandler = newOrderedTextHandler(os.Stderr, slog.LevelInfo)
+	logger, err := newLogger(cfg.LogFilePath, cfg.LogFormat, cfg.LogLevel)
+	if err != nil {
+		return err
 	}
-	logger := slog.New(slogHandler)
 	// Set as default logger for global slog calls
 	slog.SetDefault(logger)
 
+	shutdownTracing, err := setupTracing(context.Background(), cfg.OTLPEndpoint, cfg.Version)
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing()
+
+	allowlist, err := newBaseURLAllowlist(cfg.BaseURL, cfg.AllowedBaseURLs)
+	if err != nil {
+		return fmt.Errorf("failed to parse allowed base URLs: %w", err)
+	}
+
+	redactor, err := mcplog.NewRedactor(cfg.Redaction)
+	if err != nil {
+		return fmt.Errorf("failed to configure log redaction: %w", err)
+	}
+
+	auditor, err := newAuditLogger(cfg.AuditLog)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if auditor != nil {
+		defer func() { _ = auditor.Close() }()
+	}
+
 	// Create translation helper
 	t, _ := translations.TranslationHelper()
 
@@ -338,20 +585,19 @@ func RunHTTPServer(cfg HTTPServerConfig) error {
 		Version:         cfg.Version,
 		Translator:      t,
 		EnabledToolsets: []string{"all"},
+		Redactor:        redactor,
+		Auditor:         auditor,
+		Retry:           cfg.Retry,
+		Cache:           cfg.Cache,
+		OutputFormat:    flashduty.ParseOutputFormat(cfg.OutputFormat),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
 	}
 
 	httpServer := newStreamableHTTPServer(mcpServer, logger, func(ctx context.Context, r *http.Request) context.Context {
-		// Extract W3C Trace Context from HTTP headers, or generate a new one
-		traceCtx, err := trace.FromHTTPHeadersOrNew(r.Header)
-		if err != nil {
-			logger.Warn("Failed to generate trace context, continuing without trace", "error", err)
-			// Continue without trace context if generation fails
-		} else {
-			ctx = trace.ContextWithTraceContext(ctx, traceCtx)
-		}
+		// The W3C Trace Context is already on ctx: withRequestSpan resolves it
+		// from the request headers (or generates one) before we get here.
 
 		// Note: HTTP request logging is handled by MCP hooks (OnBeforeAny, OnSuccess, OnError)
 		// which provide more detailed information including method, params, and results.
@@ -359,9 +605,18 @@ func RunHTTPServer(cfg HTTPServerConfig) error {
 		return httpContextFunc(ctx, r, cfg.BaseURL)
 	})
 
+	mcpHandler := withRequestSpan(requireAllowedBaseURL(httpServer, allowlist))
+
+	// shuttingDown flips /readyz to 503 as soon as a shutdown signal arrives,
+	// ShutdownDrain before the listener closes.
+	var shuttingDown atomic.Bool
+
 	mux := http.NewServeMux()
-	mux.Handle("/mcp", httpServer)
-	mux.Handle("/flashduty", httpServer) // Keep for backward compatibility
+	mux.Handle("/mcp", mcpHandler)
+	mux.Handle("/flashduty", mcpHandler) // Keep for backward compatibility
+	mux.HandleFunc("/healthz", healthzHandler)
+	mux.Handle("/readyz", readyzHandler(&shuttingDown))
+	mux.Handle("/metrics", metricsHandler())
 
 	srv := &http.Server{
 		Addr:              ":" + cfg.Port,
@@ -393,18 +648,35 @@ func RunHTTPServer(cfg HTTPServerConfig) error {
 	// Wait for shutdown signal or server error
 	select {
 	case <-ctx.Done():
-		logger.Info("Shutting down server...")
+		// A second signal now terminates the process without draining.
+		stop()
 	case err := <-errC:
 		return fmt.Errorf("listen failed: %w", err)
 	}
 
+	if err := drainAndShutdown(srv, &shuttingDown, cfg.ShutdownDrain, logger); err != nil {
+		return err
+	}
+
+	logger.Info("Server exited properly")
+	return nil
+}
+
+// drainAndShutdown flips readiness to 503, keeps serving for drain so
+// readiness probes can observe it, then shuts srv down.
+func drainAndShutdown(srv *http.Server, shuttingDown *atomic.Bool, drain time.Duration, logger *slog.Logger) error {
+	shuttingDown.Store(true)
+	if drain > 0 {
+		logger.Info("Draining before shutdown", "drain", drain)
+		time.Sleep(drain)
+	}
+
+	logger.Info("Shutting down server...")
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		return fmt.Errorf("server shutdown failed: %w", err)
 	}
-
-	logger.Info("Server exited properly")
 	return nil
 }
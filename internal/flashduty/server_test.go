@@ -6,9 +6,16 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/flashduty"
 	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
 )
 
@@ -55,3 +62,155 @@ func TestNewStreamableHTTPServer_RejectsSSEGet(t *testing.T) {
 		t.Fatalf("expected 405 Method Not Allowed for SSE GET, got %d", resp.StatusCode)
 	}
 }
+
+// TestSessionToolFilter_HonorsReadOnlyAndToolsets asserts that the per-session
+// config injected by httpContextFunc narrows tools/list and refuses tools/call
+// for tools outside it, even though the shared server registers everything.
+func TestSessionToolFilter_HonorsReadOnlyAndToolsets(t *testing.T) {
+	t.Parallel()
+
+	mcpServer, err := NewMCPServer(FlashdutyConfig{
+		Version:         "test",
+		Translator:      translations.NullTranslationHelper,
+		EnabledToolsets: []string{"all"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create MCP server: %v", err)
+	}
+
+	listTools := func(ctx context.Context) map[string]bool {
+		t.Helper()
+		resp := mcpServer.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+		result, ok := resp.(mcp.JSONRPCResponse)
+		if !ok {
+			t.Fatalf("expected JSONRPCResponse, got %T", resp)
+		}
+		list, ok := result.Result.(mcp.ListToolsResult)
+		if !ok {
+			t.Fatalf("expected ListToolsResult, got %T", result.Result)
+		}
+		names := make(map[string]bool, len(list.Tools))
+		for _, tool := range list.Tools {
+			names[tool.Name] = true
+		}
+		return names
+	}
+
+	all := listTools(context.Background())
+	if !all["close_incident"] || !all["query_members"] {
+		t.Fatalf("expected unfiltered list without session config, got %v", all)
+	}
+
+	readOnlyCtx := ContextWithConfig(context.Background(), FlashdutyConfig{
+		EnabledToolsets: []string{"incidents"},
+		ReadOnly:        true,
+	})
+	got := listTools(readOnlyCtx)
+	if got["close_incident"] {
+		t.Error("close_incident must be hidden from a read-only session")
+	}
+	if got["query_members"] {
+		t.Error("query_members must be hidden when only incidents is enabled")
+	}
+	if !got["query_incidents"] {
+		t.Error("query_incidents should remain visible")
+	}
+
+	resp := mcpServer.HandleMessage(readOnlyCtx, []byte(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"close_incident","arguments":{"incident_ids":"abc"}}}`))
+	if _, ok := resp.(mcp.JSONRPCError); !ok {
+		t.Fatalf("expected close_incident call to be refused, got %T", resp)
+	}
+}
+
+// TestOutputFormat_PerSession asserts that sessions of one server get the
+// format they asked for over HTTP, falling back to the server default, and that
+// a tool's output_format argument wins over both.
+func TestOutputFormat_PerSession(t *testing.T) {
+	t.Parallel()
+
+	mcpServer, err := NewMCPServer(FlashdutyConfig{
+		Version:         "test",
+		Translator:      translations.NullTranslationHelper,
+		EnabledToolsets: []string{"all"},
+		OutputFormat:    flashduty.OutputFormatTOON,
+	})
+	if err != nil {
+		t.Fatalf("failed to create MCP server: %v", err)
+	}
+
+	call := func(r *http.Request, args string) string {
+		t.Helper()
+		ctx := httpContextFunc(context.Background(), r, "")
+		resp := mcpServer.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"list_template_functions","arguments":`+args+`}}`))
+		result, ok := resp.(mcp.JSONRPCResponse)
+		if !ok {
+			t.Fatalf("expected JSONRPCResponse, got %T", resp)
+		}
+		text, _ := mcp.AsTextContent(result.Result.(*mcp.CallToolResult).Content[0])
+		return text.Text
+	}
+	isJSON := func(s string) bool { return strings.HasPrefix(s, "{") }
+
+	plain := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	if isJSON(call(plain, `{}`)) {
+		t.Error("expected the server default (toon) without a client choice")
+	}
+	if !isJSON(call(httptest.NewRequest(http.MethodPost, "/mcp?output_format=json", nil), `{}`)) {
+		t.Error("expected json from ?output_format=json")
+	}
+	header := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	header.Header.Set("X-Output-Format", "json")
+	if !isJSON(call(header, `{}`)) {
+		t.Error("expected json from the X-Output-Format header")
+	}
+	if !isJSON(call(plain, `{"output_format":"json"}`)) {
+		t.Error("expected the output_format argument to override the session format")
+	}
+}
+
+// TestRequestSpan_Stdio asserts that requests arriving without an HTTP handler
+// in front, as over stdio, still get one mcp.request span each and that tool
+// spans are its children.
+func TestRequestSpan_Stdio(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	mcpServer, err := NewMCPServer(FlashdutyConfig{
+		Version:         "test",
+		Translator:      translations.NullTranslationHelper,
+		EnabledToolsets: []string{"all"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create MCP server: %v", err)
+	}
+
+	ctx := context.Background()
+	mcpServer.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	mcpServer.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"list_template_functions","arguments":{}}}`))
+
+	var requests []sdktrace.ReadOnlySpan
+	var tool sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		switch span.Name() {
+		case "mcp.request":
+			requests = append(requests, span)
+		case "tools/call list_template_functions":
+			tool = span
+		}
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected one mcp.request span per request, got %d", len(requests))
+	}
+	if tool == nil {
+		t.Fatal("expected a tool span")
+	}
+	call := requests[1]
+	if tool.Parent().SpanID() != call.SpanContext().SpanID() || tool.SpanContext().TraceID() != call.SpanContext().TraceID() {
+		t.Errorf("tool span parent = %s, want request span %s", tool.Parent().SpanID(), call.SpanContext().SpanID())
+	}
+	if requests[0].SpanContext().TraceID() == call.SpanContext().TraceID() {
+		t.Error("expected each request to start its own trace")
+	}
+}
@@ -0,0 +1,141 @@
+package flashduty
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/trace"
+)
+
+const toolNameKey = contextKey("toolName")
+
+// toolNameFromContext returns the name of the tool whose handler is running,
+// so outbound API spans can be attributed to it.
+func toolNameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(toolNameKey).(string)
+	return name
+}
+
+// withRequestSpan wraps the MCP HTTP handler so that every request runs under
+// one server span. The W3C trace context is resolved here, before mcp-go calls
+// the HTTP context func, so the span, log lines and outbound headers all share
+// the caller's trace ID (or a fresh one when no traceparent was sent).
+func withRequestSpan(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if tc := trace.FromHTTPHeaders(r.Header); tc != nil {
+			ctx = trace.ContextWithTraceContext(ctx, tc)
+		}
+
+		ctx, span := trace.StartSpan(ctx, "mcp.request",
+			oteltrace.WithSpanKind(oteltrace.SpanKindServer),
+			oteltrace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+			),
+		)
+		defer span.End()
+
+		// No exporter (or not sampled): fall back to the propagation-only
+		// behavior of generating a trace ID for log correlation.
+		if trace.FromContext(ctx) == nil {
+			if tc, err := trace.NewTraceContext(); err != nil {
+				slog.Warn("Failed to generate trace context, continuing without trace", "error", err)
+			} else {
+				ctx = trace.ContextWithTraceContext(ctx, tc)
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// requestSpanHooks gives transports without an HTTP handler to wrap, i.e.
+// stdio, the same mcp.request span per MCP request that withRequestSpan opens
+// for HTTP. Hooks cannot replace the context, so a tools/call request carries
+// the span to toolTracingMiddleware as a traceparent header, which stdio
+// requests otherwise never have.
+func requestSpanHooks(hooks *server.Hooks) {
+	// spans holds each in-flight request's span, keyed by the request message
+	// as with the duration tracking in NewMCPServer.
+	var spans sync.Map
+
+	hooks.AddBeforeAny(func(ctx context.Context, _ any, method mcp.MCPMethod, message any) {
+		// HTTP requests already run under withRequestSpan.
+		if trace.FromContext(ctx) != nil || !isPointer(message) {
+			return
+		}
+		ctx, span := trace.StartSpan(ctx, "mcp.request",
+			oteltrace.WithSpanKind(oteltrace.SpanKindServer),
+			oteltrace.WithAttributes(attribute.String("mcp.method", string(method))),
+		)
+		spans.Store(message, span)
+		if req, ok := message.(*mcp.CallToolRequest); ok {
+			if tc := trace.FromContext(ctx); tc != nil {
+				if req.Header == nil {
+					req.Header = http.Header{}
+				}
+				tc.SetHTTPHeaders(req.Header)
+			}
+		}
+	})
+	hooks.AddOnSuccess(func(_ context.Context, _ any, _ mcp.MCPMethod, message any, _ any) {
+		if span, ok := spans.LoadAndDelete(message); ok {
+			span.(oteltrace.Span).End()
+		}
+	})
+	hooks.AddOnError(func(_ context.Context, _ any, _ mcp.MCPMethod, message any, err error) {
+		if span, ok := spans.LoadAndDelete(message); ok {
+			span := span.(oteltrace.Span)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			span.End()
+		}
+	})
+}
+
+// toolTracingMiddleware records a child span per tool call and makes the tool
+// name available to the outbound API spans it triggers.
+func toolTracingMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		tool := request.Params.Name
+		argsSize := 0
+		if data, err := json.Marshal(request.GetArguments()); err == nil {
+			argsSize = len(data)
+		}
+
+		ctx = context.WithValue(ctx, toolNameKey, tool)
+		if trace.FromContext(ctx) == nil {
+			// stdio: parent to the span requestSpanHooks opened.
+			if tc := trace.FromHTTPHeaders(request.Header); tc != nil {
+				ctx = trace.ContextWithTraceContext(ctx, tc)
+			}
+		}
+		ctx, span := trace.StartSpan(ctx, "tools/call "+tool,
+			oteltrace.WithAttributes(
+				attribute.String("mcp.tool.name", tool),
+				attribute.Int("mcp.tool.arguments_size", argsSize),
+			),
+		)
+		defer span.End()
+
+		result, err := next(ctx, request)
+		switch {
+		case err != nil:
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		case result != nil && result.IsError:
+			span.SetStatus(codes.Error, "tool returned an error result")
+		}
+		return result, err
+	}
+}
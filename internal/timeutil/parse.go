@@ -99,6 +99,20 @@ func ParseAny(v any) (int64, error) {
 	}
 }
 
+// ParseDuration parses a positive length of time such as "30m", "2h" or "7d"
+// (day shorthand is expanded to hours, as in Parse).
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	d, err := time.ParseDuration(expandDays(s))
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse duration %q: expected a duration like 30m, 2h or 7d", s)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration %q must be positive", s)
+	}
+	return d, nil
+}
+
 // expandDays converts day shorthand (e.g. "7d", "30d") to hours for time.ParseDuration.
 // time.ParseDuration does not natively support "d" because day length is calendar-dependent.
 func expandDays(s string) string {
@@ -74,3 +74,34 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "30m", want: 30 * time.Minute},
+		{input: " 2h ", want: 2 * time.Hour},
+		{input: "1h30m", want: 90 * time.Minute},
+		{input: "7d", want: 7 * 24 * time.Hour},
+		{input: "0m", wantErr: true},
+		{input: "-5m", wantErr: true},
+		{input: "2026-04-01", wantErr: true},
+		{input: "", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ParseDuration(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("ParseDuration(%q) expected error, got %v", tc.input, got)
+				}
+				return
+			}
+			if err != nil || got != tc.want {
+				t.Fatalf("ParseDuration(%q) = %v, %v; want %v", tc.input, got, err, tc.want)
+			}
+		})
+	}
+}
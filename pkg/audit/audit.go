@@ -0,0 +1,212 @@
+// Package audit writes an append-only, hash-chained record of the changes
+// made through the server's write tools.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// Outcomes recorded in Entry.Outcome.
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+)
+
+// Client identifies the MCP client that made the call, as announced in its
+// initialize request.
+type Client struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// Entry is one audited tool call. Hash covers every other field, including
+// PrevHash, so editing, reordering or dropping a line breaks the chain.
+type Entry struct {
+	Time              time.Time           `json:"time"`
+	TraceID           string              `json:"trace_id,omitempty"`
+	APPKeyFingerprint string              `json:"app_key_fingerprint,omitempty"`
+	Client            Client              `json:"client"`
+	Tool              string              `json:"tool"`
+	Arguments         map[string]any      `json:"arguments"`
+	AffectedIDs       map[string][]string `json:"affected_ids,omitempty"`
+	Outcome           string              `json:"outcome"`
+	Error             string              `json:"error,omitempty"`
+	DurationMillis    int64               `json:"duration_ms"`
+	PrevHash          string              `json:"prev_hash"`
+}
+
+// Fingerprint identifies an APP key without revealing it: the first 16 hex
+// digits of its SHA-256.
+func Fingerprint(appKey string) string {
+	if appKey == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(appKey))
+	return "sha256:" + hex.EncodeToString(sum[:])[:16]
+}
+
+// Logger appends entries to a sink, one JSON object per line, chaining each
+// line to the previous one by hash. It is safe for concurrent use.
+type Logger struct {
+	mu       sync.Mutex
+	w        io.Writer
+	closer   io.Closer
+	prevHash string
+}
+
+// NewLogger returns a Logger writing to w whose first entry chains to
+// prevHash (empty for a new trail).
+func NewLogger(w io.Writer, prevHash string) *Logger {
+	l := &Logger{w: w, prevHash: prevHash}
+	if c, ok := w.(io.Closer); ok {
+		l.closer = c
+	}
+	return l
+}
+
+// NewFileLogger opens path for appending, creating it if needed, and resumes
+// the hash chain from its last line.
+func NewFileLogger(path string) (*Logger, error) {
+	// #nosec G304
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	prevHash, err := lastHash(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to read audit log %s: %w", path, err)
+	}
+	return NewLogger(f, prevHash), nil
+}
+
+// Log stamps e with the previous entry's hash and appends it.
+func (l *Logger) Log(e Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e.PrevHash = l.prevHash
+	line, hash, err := sealEntry(e)
+	if err != nil {
+		return err
+	}
+	if _, err := l.w.Write(line); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	l.prevHash = hash
+	return nil
+}
+
+// Close closes the underlying sink when it is closable.
+func (l *Logger) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
+
+// sealEntry renders e as a JSON line with a trailing "hash" member computed
+// over the bytes before it. Hashing the exact bytes written, rather than a
+// re-encoding, keeps verification independent of JSON number and key handling.
+func sealEntry(e Entry) (line []byte, hash string, err error) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	hash = hex.EncodeToString(sum[:])
+
+	line = make([]byte, 0, len(body)+len(hash)+12)
+	line = append(line, body[:len(body)-1]...)
+	line = append(line, `,"hash":"`...)
+	line = append(line, hash...)
+	line = append(line, "\"}\n"...)
+	return line, hash, nil
+}
+
+var hashSuffix = regexp.MustCompile(`,"hash":"([0-9a-f]{64})"}$`)
+
+// openLine splits a sealed line into the hashed body and the recorded hash.
+func openLine(line []byte) (body []byte, hash string, ok bool) {
+	m := hashSuffix.FindSubmatchIndex(line)
+	if m == nil {
+		return nil, "", false
+	}
+	body = append(append([]byte(nil), line[:m[0]]...), '}')
+	return body, string(line[m[2]:m[3]]), true
+}
+
+// Verify checks a trail line by line: every hash must match its line and every
+// prev_hash must match the line before it. It returns the number of entries
+// verified and the first inconsistency found.
+func Verify(r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	n, prevHash := 0, ""
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		body, hash, ok := openLine(line)
+		if !ok {
+			return n, fmt.Errorf("entry %d: missing hash", n+1)
+		}
+		sum := sha256.Sum256(body)
+		if hex.EncodeToString(sum[:]) != hash {
+			return n, fmt.Errorf("entry %d: hash mismatch, entry was modified", n+1)
+		}
+		var e Entry
+		if err := json.Unmarshal(body, &e); err != nil {
+			return n, fmt.Errorf("entry %d: %w", n+1, err)
+		}
+		if n > 0 && e.PrevHash != prevHash {
+			return n, fmt.Errorf("entry %d: prev_hash does not match entry %d, entries were removed or reordered", n+1, n)
+		}
+		prevHash = hash
+		n++
+	}
+	return n, scanner.Err()
+}
+
+// lastHash returns the hash of the last entry in f, or "" when f is empty.
+func lastHash(f *os.File) (string, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	const maxTail = 1 << 20
+	size := info.Size()
+	if size == 0 {
+		return "", nil
+	}
+	offset := max(size-maxTail, 0)
+	buf := make([]byte, size-offset)
+	if _, err := f.ReadAt(buf, offset); err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	buf = bytes.TrimRight(buf, "\n")
+	if i := bytes.LastIndexByte(buf, '\n'); i >= 0 {
+		buf = buf[i+1:]
+	} else if offset > 0 {
+		return "", fmt.Errorf("last entry exceeds %d bytes", maxTail)
+	}
+	_, hash, ok := openLine(bytes.TrimSpace(buf))
+	if !ok {
+		return "", fmt.Errorf("last line is not a sealed audit entry")
+	}
+	return hash, nil
+}
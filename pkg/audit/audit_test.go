@@ -0,0 +1,89 @@
+package audit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testEntry(tool string) Entry {
+	return Entry{
+		Time:              time.Date(2026, 4, 1, 10, 0, 0, 0, time.UTC),
+		TraceID:           "4bf92f3577b34da6a3ce929d0e0e4736",
+		APPKeyFingerprint: Fingerprint("secret-key"),
+		Client:            Client{Name: "test-client", Version: "1.0"},
+		Tool:              tool,
+		Arguments:         map[string]any{"incident_ids": "a,b", "num": 9007199254740993},
+		AffectedIDs:       map[string][]string{"incident_id": {"a", "b"}},
+		Outcome:           OutcomeSuccess,
+	}
+}
+
+func TestLoggerChainsEntries(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(&buf, "")
+	for _, tool := range []string{"ack_incident", "close_incident", "update_incident"} {
+		if err := l.Log(testEntry(tool)); err != nil {
+			t.Fatalf("Log: %v", err)
+		}
+	}
+
+	if strings.Contains(buf.String(), "secret-key") {
+		t.Fatal("APP key leaked into the audit trail")
+	}
+	n, err := Verify(bytes.NewReader(buf.Bytes()))
+	if err != nil || n != 3 {
+		t.Fatalf("Verify = %d, %v; want 3, nil", n, err)
+	}
+
+	lines := strings.SplitAfter(buf.String(), "\n")
+
+	tampered := strings.Replace(buf.String(), "close_incident", "ack_incident", 1)
+	if _, err := Verify(strings.NewReader(tampered)); err == nil || !strings.Contains(err.Error(), "modified") {
+		t.Errorf("edited entry: err = %v", err)
+	}
+
+	dropped := lines[0] + lines[2]
+	if _, err := Verify(strings.NewReader(dropped)); err == nil || !strings.Contains(err.Error(), "removed or reordered") {
+		t.Errorf("dropped entry: err = %v", err)
+	}
+}
+
+func TestFileLoggerResumesChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	for i := 0; i < 2; i++ {
+		l, err := NewFileLogger(path)
+		if err != nil {
+			t.Fatalf("NewFileLogger: %v", err)
+		}
+		if err := l.Log(testEntry("create_incident")); err != nil {
+			t.Fatalf("Log: %v", err)
+		}
+		if err := l.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	if n, err := Verify(f); err != nil || n != 2 {
+		t.Fatalf("Verify = %d, %v; want 2, nil", n, err)
+	}
+}
+
+func TestNewFileLoggerRejectsForeignFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	if err := os.WriteFile(path, []byte("not an audit entry\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileLogger(path); err == nil {
+		t.Fatal("expected an error for a file that is not an audit trail")
+	}
+}
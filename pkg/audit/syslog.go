@@ -0,0 +1,18 @@
+//go:build !windows && !plan9
+
+package audit
+
+import (
+	"fmt"
+	"log/syslog"
+)
+
+// NewSyslogLogger sends entries to the local syslog daemon under tag, with
+// facility auth and severity notice.
+func NewSyslogLogger(tag string) (*Logger, error) {
+	w, err := syslog.New(syslog.LOG_AUTH|syslog.LOG_NOTICE, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+	return NewLogger(w, ""), nil
+}
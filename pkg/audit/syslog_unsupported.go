@@ -0,0 +1,10 @@
+//go:build windows || plan9
+
+package audit
+
+import "errors"
+
+// NewSyslogLogger is not available on this platform; use an audit log file.
+func NewSyslogLogger(string) (*Logger, error) {
+	return nil, errors.New("syslog audit sink is not supported on this platform")
+}
@@ -3,14 +3,270 @@ package flashduty
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	flashduty "github.com/flashcatcloud/go-flashduty"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/flashcatcloud/flashduty-mcp-server/internal/timeutil"
 	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
 )
 
+// defaultAlertWindow is the lookback query_alerts applies when the caller
+// omits both since and until.
+const defaultAlertWindow = 24 * time.Hour
+
+// maxAlertScan caps how many alerts query_alerts reads when `labels` or
+// `query` must be applied client-side, since /alert/list supports neither.
+const maxAlertScan = 2000
+
+// maxAlertLookup caps alert_ids per find_incidents_for_alert call, and
+// maxAlertIncidents how many owning incidents one call looks up.
+const (
+	maxAlertLookup    = 100
+	maxAlertIncidents = 100
+)
+
+const queryAlertsDescription = `Query alerts by time range, channel, severity, status, labels, or free-text query, including alerts that never became incidents. Each alert carries its owning incident (incident.incident_id, empty if none). Use query_alert_events(alert_id=...) for the raw events behind one alert.`
+
+const alertSinceDescription = SinceDescription +
+	" You may omit BOTH since and until; the tool then defaults to the last 24 hours."
+
+// QueryAlerts creates a tool to query alerts across channels.
+func QueryAlerts(getClient GetFlashdutyClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("query_alerts",
+			mcp.WithDescription(t("TOOL_QUERY_ALERTS_DESCRIPTION", queryAlertsDescription)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_QUERY_ALERTS_USER_TITLE", "Query alerts"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithSince(mcp.Description(alertSinceDescription)),
+			WithUntil(),
+			mcp.WithString("channel_ids", mcp.Description("Comma-separated collaboration space IDs to filter by.")),
+			mcp.WithString("severity", mcp.Description("Filter by severity. Valid values: Critical, Warning, Info. Comma-separated for multiple.")),
+			mcp.WithString("status", mcp.Description("Filter by status: active (still firing) or recovered."), mcp.Enum("active", "recovered")),
+			mcp.WithString("labels", mcp.Description("Comma-separated label filters, all of which must match: key=value for an exact value, or a bare key for any value (e.g. \"service=checkout,env=prod,pod\").")),
+			mcp.WithString("query", mcp.Description("Case-insensitive text matched against title, description, alert_key and label values."), mcp.MaxLength(200)),
+			mcp.WithNumber("limit", mcp.Description(LimitDescription), mcp.DefaultNumber(20), mcp.Min(1), mcp.Max(100)),
+			mcp.WithNumber("page", mcp.Description(PageDescription), mcp.DefaultNumber(1), mcp.Min(1)),
+			withFieldsParam(),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Flashduty client: %w", err)
+			}
+
+			args := request.GetArguments()
+			channelIdsStr, _ := OptionalParam[string](request, "channel_ids")
+			severity, _ := OptionalParam[string](request, "severity")
+			status, _ := OptionalParam[string](request, "status")
+			labelsStr, _ := OptionalParam[string](request, "labels")
+			query, _ := OptionalParam[string](request, "query")
+			limit, page := optionalPaging(request, defaultQueryLimit)
+			if page < 1 {
+				page = 1
+			}
+
+			startTime, endTime, err := alertWindow(args)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			req := &flashduty.AlertListRequest{
+				AlertSeverity: severity,
+				StartTime:     startTime,
+				EndTime:       endTime,
+			}
+			switch status {
+			case "":
+			case "active", "recovered":
+				active := status == "active"
+				req.IsActive = &active
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid status %q: use active or recovered", status)), nil
+			}
+			if channelIdsStr != "" {
+				channelIDs := parseCommaSeparatedInts(channelIdsStr)
+				if len(channelIDs) == 0 {
+					return mcp.NewToolResultError("channel_ids must contain at least one valid ID when specified"), nil
+				}
+				req.ChannelIDs = make([]int64, len(channelIDs))
+				for i, id := range channelIDs {
+					req.ChannelIDs[i] = int64(id)
+				}
+			}
+			match, err := newAlertMatcher(labelsStr, query)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if match == nil {
+				req.Limit = limit
+				if page > 1 {
+					req.Page = page
+				}
+				out, _, err := client.New.Alerts.ReadList(ctx, req)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve alerts: %v", err)), nil
+				}
+				total := int(out.Total)
+				return MarshalResult(ctx, addPageHint(map[string]any{
+					"alerts": optionalProjection(request).apply(out.Items),
+					"total":  total,
+				}, len(out.Items), total, page, limit)), nil
+			}
+
+			// The backend cannot filter by labels or text, so scan the window
+			// and filter here. Paging then runs over the matches, which keeps
+			// page/limit/total meaning the same thing they do without filters.
+			alerts, complete, err := scanAlerts(ctx, client, req, match, maxAlertScan)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve alerts: %v", err)), nil
+			}
+			from := min((page-1)*limit, len(alerts))
+			pageItems := alerts[from:min(from+limit, len(alerts))]
+			result := addPageHint(map[string]any{
+				"alerts": optionalProjection(request).apply(pageItems),
+				"total":  len(alerts),
+			}, len(pageItems), len(alerts), page, limit)
+			if !complete {
+				result["truncated"] = true
+				result["scan_hint"] = fmt.Sprintf("labels/query are matched client-side and only the latest %d alerts in the window were scanned. Narrow since/until, channel_ids, severity or status to cover the rest.", maxAlertScan)
+			}
+			return MarshalResult(ctx, result), nil
+		}
+}
+
+// alertWindow resolves since/until for the alert tools, defaulting to the
+// last defaultAlertWindow when both are omitted.
+func alertWindow(args map[string]any) (since, until int64, err error) {
+	since, err = timeutil.ParseAny(args["since"])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid since: %v", err)
+	}
+	until, err = parseUntilArg(args["until"])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid until: %v", err)
+	}
+	if !argProvided(args["since"]) {
+		if argProvided(args["until"]) {
+			return 0, 0, fmt.Errorf("`since` is required when `until` is set; omit both to default to the last 24 hours")
+		}
+		since = until - int64(defaultAlertWindow/time.Second)
+	}
+	if err := validateTimeWindow(since, until); err != nil {
+		return 0, 0, err
+	}
+	return since, until, nil
+}
+
+// scanAlerts reads the alerts matching req newest first, keeping those that
+// satisfy match (all of them when match is nil). complete is false when
+// maxScan alerts were read before the window was exhausted.
+func scanAlerts(ctx context.Context, client *Clients, req *flashduty.AlertListRequest, match func(flashduty.AlertItem) bool, maxScan int) (alerts []flashduty.AlertItem, complete bool, err error) {
+	scan := *req
+	scan.Limit = 100
+	for scanned, page := 0, 1; ; page++ {
+		scan.Page = page
+		out, _, err := client.New.Alerts.ReadList(ctx, &scan)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, a := range out.Items {
+			if match == nil || match(a) {
+				alerts = append(alerts, a)
+			}
+		}
+		scanned += len(out.Items)
+		if !out.HasNextPage || len(out.Items) == 0 {
+			return alerts, true, nil
+		}
+		if scanned >= maxScan {
+			return alerts, false, nil
+		}
+		scan.SearchAfterCtx = out.SearchAfterCtx
+	}
+}
+
+// newAlertMatcher builds the client-side filter for the `labels` and `query`
+// arguments. It returns nil when neither is set.
+func newAlertMatcher(labels, query string) (func(flashduty.AlertItem) bool, error) {
+	filters, err := parseLabelFilters(labels)
+	if err != nil {
+		return nil, err
+	}
+	query = strings.ToLower(strings.TrimSpace(query))
+	if len(filters) == 0 && query == "" {
+		return nil, nil
+	}
+	return func(a flashduty.AlertItem) bool {
+		if !filters.match(a.Labels) {
+			return false
+		}
+		if query == "" {
+			return true
+		}
+		for _, s := range []string{a.Title, a.Description, a.AlertKey} {
+			if strings.Contains(strings.ToLower(s), query) {
+				return true
+			}
+		}
+		for _, v := range a.Labels {
+			if strings.Contains(strings.ToLower(v), query) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// labelFilters is a parsed `labels` argument. An empty value matches any
+// value of the key.
+type labelFilters map[string]string
+
+func parseLabelFilters(s string) (labelFilters, error) {
+	filters := labelFilters{}
+	for _, pair := range parseCommaSeparatedStrings(s) {
+		key, value, _ := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("invalid label filter %q: expected key=value or key", pair)
+		}
+		filters[key] = strings.TrimSpace(value)
+	}
+	return filters, nil
+}
+
+// parseLabels parses a comma-separated list of key=value pairs.
+func parseLabels(s string) (map[string]string, error) {
+	pairs := parseCommaSeparatedStrings(s)
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	labels := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("invalid label %q: expected key=value", pair)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
+func (f labelFilters) match(labels map[string]string) bool {
+	for key, want := range f {
+		got, ok := labels[key]
+		if !ok || (want != "" && got != want) {
+			return false
+		}
+	}
+	return true
+}
+
 const queryAlertEventsDescription = `Query raw events for a single alert. Returns the upstream event stream that produced the alert (e.g. each individual Prometheus firing).`
 
 // QueryAlertEvents creates a tool to query raw events of a single alert.
@@ -22,6 +278,7 @@ func QueryAlertEvents(getClient GetFlashdutyClientFn, t translations.Translation
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("alert_id", mcp.Required(), mcp.Description("Alert ID whose raw events should be returned.")),
+			withFieldsParam(),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			ctx, client, err := getClient(ctx)
 			if err != nil {
@@ -38,8 +295,323 @@ func QueryAlertEvents(getClient GetFlashdutyClientFn, t translations.Translation
 				return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve alert events: %v", err)), nil
 			}
 
-			return MarshalResult(map[string]any{
-				"alert_events": out.Items,
+			return MarshalResult(ctx, map[string]any{
+				"alert_events": optionalProjection(request).apply(out.Items),
 			}), nil
 		}
 }
+
+// alertIncidentFields is the slice of an incident find_incidents_for_alert
+// returns.
+var alertIncidentFields = newProjection("incident_id,num,title,incident_severity,progress,channel_id,channel_name,start_time,close_time,responders.person_id,responders.person_name,responders.acknowledged_at,detail_url")
+
+const findIncidentsForAlertDescription = `Find the incidents that alerts were grouped into. Accepts alert IDs (as pasted from a notification or query_alerts), or label filters such as "host=db-07" matched against alerts in a time window. Returns each owning incident with its progress and responders, the IDs of the matched alerts it holds, the alerts that belong to no incident, and any requested alert IDs that were not found.`
+
+// FindIncidentsForAlert creates a tool that maps alerts to their incidents.
+func FindIncidentsForAlert(getClient GetFlashdutyClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("find_incidents_for_alert",
+			mcp.WithDescription(t("TOOL_FIND_INCIDENTS_FOR_ALERT_DESCRIPTION", findIncidentsForAlertDescription)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FIND_INCIDENTS_FOR_ALERT_USER_TITLE", "Find incidents for alerts"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("alert_ids", mcp.Description("Comma-separated alert IDs. When given, labels and the time window are ignored.")),
+			mcp.WithString("labels", mcp.Description("Comma-separated label filters, all of which must match: key=value for an exact value, or a bare key for any value (e.g. \"host=db-07\").")),
+			WithSince(mcp.Description(alertSinceDescription)),
+			WithUntil(),
+			mcp.WithString("channel_ids", mcp.Description("Comma-separated collaboration space IDs to narrow a label search.")),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Flashduty client: %w", err)
+			}
+
+			alertIdsStr, _ := OptionalParam[string](request, "alert_ids")
+			labelsStr, _ := OptionalParam[string](request, "labels")
+			channelIdsStr, _ := OptionalParam[string](request, "channel_ids")
+
+			var alerts []flashduty.AlertItem
+			var notFound []string
+			complete := true
+			switch {
+			case alertIdsStr != "":
+				alertIDs := parseCommaSeparatedStrings(alertIdsStr)
+				if len(alertIDs) > maxAlertLookup {
+					return mcp.NewToolResultError(fmt.Sprintf("at most %d alert_ids per call", maxAlertLookup)), nil
+				}
+				out, _, err := client.New.Alerts.ReadListByIDs(ctx, &flashduty.AlertListByIDsRequest{AlertIDs: alertIDs})
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve alerts: %v", err)), nil
+				}
+				alerts = out.Items
+				notFound = missingAlertIDs(alertIDs, alerts)
+			case labelsStr != "":
+				match, err := newAlertMatcher(labelsStr, "")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				startTime, endTime, err := alertWindow(request.GetArguments())
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				req := &flashduty.AlertListRequest{StartTime: startTime, EndTime: endTime}
+				if channelIdsStr != "" {
+					channelIDs := parseCommaSeparatedInts(channelIdsStr)
+					if len(channelIDs) == 0 {
+						return mcp.NewToolResultError("channel_ids must contain at least one valid ID when specified"), nil
+					}
+					req.ChannelIDs = make([]int64, len(channelIDs))
+					for i, id := range channelIDs {
+						req.ChannelIDs[i] = int64(id)
+					}
+				}
+				alerts, complete, err = scanAlerts(ctx, client, req, match, maxAlertScan)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve alerts: %v", err)), nil
+				}
+			default:
+				return mcp.NewToolResultError("provide alert_ids or labels"), nil
+			}
+
+			// Group the matched alerts by incident, keeping first-seen order
+			// (newest first for a label search).
+			var incidentIDs, orphans []string
+			matched := map[string][]string{}
+			for _, a := range alerts {
+				id := a.Incident.IncidentID
+				if id == "" {
+					orphans = append(orphans, a.AlertID)
+					continue
+				}
+				if _, ok := matched[id]; !ok {
+					incidentIDs = append(incidentIDs, id)
+				}
+				matched[id] = append(matched[id], a.AlertID)
+			}
+
+			result := map[string]any{"total_alerts": len(alerts)}
+			if len(orphans) > 0 {
+				result["alerts_without_incident"] = orphans
+			}
+			if len(notFound) > 0 {
+				result["alerts_not_found"] = notFound
+			}
+			if len(incidentIDs) > maxAlertIncidents {
+				result["truncated"] = true
+				result["hint"] = fmt.Sprintf("The alerts belong to %d incidents; only the %d most recently matched are shown. Narrow labels, since/until or channel_ids.", len(incidentIDs), maxAlertIncidents)
+				incidentIDs = incidentIDs[:maxAlertIncidents]
+			}
+			if !complete {
+				result["truncated"] = true
+				result["scan_hint"] = fmt.Sprintf("Labels are matched client-side and only the latest %d alerts in the window were scanned. Narrow since/until or channel_ids to cover the rest.", maxAlertScan)
+			}
+
+			incidents := []any{}
+			if len(incidentIDs) > 0 {
+				out, _, err := client.New.Incidents.ListByIDs(ctx, &flashduty.ListIncidentsByIDsRequest{IncidentIDs: incidentIDs})
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve incidents: %v", err)), nil
+				}
+				for _, item := range out.Items {
+					entry, ok := alertIncidentFields.apply(item).(map[string]any)
+					if !ok {
+						continue
+					}
+					entry["matched_alert_ids"] = matched[item.IncidentID]
+					incidents = append(incidents, entry)
+				}
+			}
+			result["incidents"] = incidents
+			return MarshalResult(ctx, result), nil
+		}
+}
+
+// missingAlertIDs returns the requested IDs that no alert in found carries.
+func missingAlertIDs(requested []string, found []flashduty.AlertItem) []string {
+	seen := make(map[string]bool, len(found))
+	for _, a := range found {
+		seen[a.AlertID] = true
+	}
+	var missing []string
+	for _, id := range requested {
+		if !seen[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+// alertResolveTimeout bounds how long send_alert_event waits for a pushed
+// event to surface as an alert and incident; alertResolvePoll is the interval
+// between lookups.
+const (
+	alertResolveTimeout = 15 * time.Second
+	alertResolvePoll    = time.Second
+)
+
+const sendAlertEventDescription = `Send a test alert event to an integration, to check routing, grouping and escalation end to end. Posts a standard alert event to the integration whose push key is given, then waits briefly for it to become an alert and incident and returns their IDs. Events with the same alert_key update the same alert; send event_status=recovered with the returned alert_key to recover it. Unlike create_incident, the event goes through the integration's routing and the channel's grouping rules.`
+
+// SendAlertEvent creates a tool that pushes a standard alert event.
+func SendAlertEvent(getClient GetFlashdutyClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("send_alert_event",
+			mcp.WithDescription(t("TOOL_SEND_ALERT_EVENT_DESCRIPTION", sendAlertEventDescription)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SEND_ALERT_EVENT_USER_TITLE", "Send alert event"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("integration_key", mcp.Required(), mcp.Description("Push key of the standard alert integration to send the event to.")),
+			mcp.WithString("title", mcp.Required(), mcp.Description("Alert title."), mcp.MaxLength(512)),
+			mcp.WithString("severity", mcp.Description("Severity of a firing event. Default Warning."), mcp.Enum("Critical", "Warning", "Info")),
+			mcp.WithString("event_status", mcp.Description("firing (default) raises or updates the alert; recovered recovers it and requires alert_key."), mcp.Enum("firing", "recovered")),
+			mcp.WithString("alert_key", mcp.Description("Deduplication key: events with the same key update one alert. Generated for a firing event when omitted.")),
+			mcp.WithString("description", mcp.Description("Alert description. Markdown is accepted.")),
+			mcp.WithString("labels", mcp.Description("Comma-separated key=value labels, e.g. \"service=checkout,env=staging\". Routing and grouping rules match on these.")),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Flashduty client: %w", err)
+			}
+
+			integrationKey, err := RequiredParam[string](request, "integration_key")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := RequiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			severity, _ := OptionalParam[string](request, "severity")
+			eventStatus, _ := OptionalParam[string](request, "event_status")
+			alertKey, _ := OptionalParam[string](request, "alert_key")
+			keyGiven := alertKey != ""
+			description, _ := OptionalParam[string](request, "description")
+			labelsStr, _ := OptionalParam[string](request, "labels")
+
+			labels, err := parseLabels(labelsStr)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// The standard alert API folds severity and recovery into one
+			// event_status field: Critical/Warning/Info, or Ok.
+			status := severity
+			switch eventStatus {
+			case "", "firing":
+				if status == "" {
+					status = "Warning"
+				}
+				if alertKey == "" {
+					alertKey = fmt.Sprintf("mcp-%d", time.Now().UnixNano())
+				}
+			case "recovered":
+				if alertKey == "" {
+					return mcp.NewToolResultError("alert_key is required to recover an alert"), nil
+				}
+				status = "Ok"
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid event_status %q: use firing or recovered", eventStatus)), nil
+			}
+			if status != "Ok" && status != "Critical" && status != "Warning" && status != "Info" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid severity %q: use Critical, Warning or Info", severity)), nil
+			}
+
+			event := map[string]any{
+				"title_rule":   title,
+				"event_status": status,
+				"alert_key":    alertKey,
+			}
+			if description != "" {
+				event["description"] = description
+			}
+			if len(labels) > 0 {
+				event["labels"] = labels
+			}
+
+			// An alert_key the caller supplied may name an alert that is
+			// already grouped; note when it was last updated, so the lookup
+			// below waits for this event rather than returning it as it was.
+			sentAt := time.Now()
+			var seen flashduty.Timestamp
+			if keyGiven {
+				if prev, err := lookupPushedAlert(ctx, client, alertKey, sentAt); err == nil && prev != nil {
+					seen = prev.LastTime
+				}
+			}
+			out, err := pushEvent(ctx, client, "/event/push/alert/standard", integrationKey, event)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Unable to push alert event: %v", err)), nil
+			}
+
+			result := map[string]any{
+				"alert_key":    alertKey,
+				"event_status": status,
+				"request_id":   out.RequestID,
+			}
+			alert, err := resolvePushedAlert(ctx, client, alertKey, sentAt, seen, status == "Ok")
+			switch {
+			case err != nil:
+				result["hint"] = fmt.Sprintf("The event was accepted, but the resulting alert could not be looked up: %v. Try find_incidents_for_alert later.", err)
+			case alert == nil:
+				result["hint"] = fmt.Sprintf("The event was accepted but no alert had appeared after %s. It may still be processing, or the integration's routing may have dropped it; check query_alerts in a minute.", alertResolveTimeout)
+			default:
+				result["alert_id"] = alert.AlertID
+				result["alert_status"] = alert.AlertStatus
+				result["channel_id"] = alert.ChannelID
+				result["channel_name"] = alert.ChannelName
+				if alert.Incident.IncidentID != "" {
+					result["incident"] = alert.Incident
+				} else if status != "Ok" {
+					result["hint"] = "The alert exists but has not been grouped into an incident yet; the channel may be holding it in a grouping window. Use find_incidents_for_alert with alert_id later."
+				}
+			}
+			return MarshalResult(ctx, result), nil
+		}
+}
+
+// resolvePushedAlert polls for the alert a pushed event updated, until it
+// shows the event (and, for a firing event, an owning incident) or
+// alertResolveTimeout passes. seen is the alert's last update before the push,
+// zero if unknown; only a later update counts as the event's. It returns the
+// last alert seen, or nil.
+func resolvePushedAlert(ctx context.Context, client *Clients, alertKey string, sentAt time.Time, seen flashduty.Timestamp, recovered bool) (*flashduty.AlertItem, error) {
+	ctx, cancel := context.WithTimeout(ctx, alertResolveTimeout)
+	defer cancel()
+
+	var last *flashduty.AlertItem
+	for {
+		alert, err := lookupPushedAlert(ctx, client, alertKey, sentAt)
+		switch {
+		case err != nil && ctx.Err() != nil:
+			return last, nil
+		case err != nil:
+			return nil, err
+		case alert != nil:
+			last = alert
+			if last.LastTime > seen && ((recovered && last.AlertStatus == "Ok") || (!recovered && last.Incident.IncidentID != "")) {
+				return last, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return last, nil
+		case <-time.After(alertResolvePoll):
+		}
+	}
+}
+
+// lookupPushedAlert returns the alert with alertKey updated around sentAt, or
+// nil if there is none. The window allows a minute of clock skew either side.
+func lookupPushedAlert(ctx context.Context, client *Clients, alertKey string, sentAt time.Time) (*flashduty.AlertItem, error) {
+	req := &flashduty.AlertListRequest{
+		AlertKeys:   []string{alertKey},
+		ByUpdatedAt: true,
+		StartTime:   sentAt.Add(-time.Minute).Unix(),
+		EndTime:     sentAt.Add(alertResolveTimeout + time.Minute).Unix(),
+	}
+	req.Limit = 1
+	out, _, err := client.New.Alerts.ReadList(ctx, req)
+	if err != nil || len(out.Items) == 0 {
+		return nil, err
+	}
+	return &out.Items[0], nil
+}
@@ -0,0 +1,241 @@
+package flashduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
+)
+
+// alertPages serves two pages of alerts from /alert/list.
+func alertPages(req map[string]any) any {
+	pages := [][]any{
+		{
+			map[string]any{"alert_id": "x1", "title": "CPU high", "labels": map[string]any{"service": "checkout", "env": "prod"}},
+			map[string]any{"alert_id": "x2", "title": "Disk full", "labels": map[string]any{"service": "search", "env": "prod"}},
+		},
+		{
+			map[string]any{"alert_id": "x3", "title": "Checkout 5xx", "labels": map[string]any{"service": "web"}},
+			map[string]any{"alert_id": "x4", "title": "CPU high", "labels": map[string]any{"service": "checkout", "env": "staging"}},
+		},
+	}
+	page := 0
+	if p, ok := req["p"].(float64); ok {
+		page = int(p) - 1
+	}
+	return map[string]any{
+		"items":         pages[page],
+		"total":         4,
+		"has_next_page": page+1 < len(pages),
+	}
+}
+
+func TestQueryAlertsPassesServerFilters(t *testing.T) {
+	t.Parallel()
+
+	api := newFakeAPI(t, map[string]fakeRoute{"/alert/list": alertPages})
+	_, handler := QueryAlerts(newTestClients(t, api.URL), translations.NullTranslationHelper)
+	out := resultText(t, callResult(t, handler, "query_alerts", map[string]any{
+		"since": "2h", "channel_ids": "3", "severity": "Critical", "status": "recovered", "limit": 2.0,
+	}))
+
+	require.Len(t, api.requests("/alert/list"), 1)
+	req := api.requests("/alert/list")[0]
+	assert.Equal(t, []any{3.0}, req["channel_ids"])
+	assert.Equal(t, "Critical", req["alert_severity"])
+	assert.Equal(t, false, req["is_active"])
+	assert.Equal(t, 2.0, req["limit"])
+	assert.EqualValues(t, 7200, req["end_time"].(float64)-req["start_time"].(float64))
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &payload))
+	assert.Len(t, payload["alerts"], 2)
+	assert.Equal(t, true, payload["truncated"])
+}
+
+func TestQueryAlertsFiltersLabelsAndQueryClientSide(t *testing.T) {
+	t.Parallel()
+
+	api := newFakeAPI(t, map[string]fakeRoute{"/alert/list": alertPages})
+	_, handler := QueryAlerts(newTestClients(t, api.URL), translations.NullTranslationHelper)
+	out := resultText(t, callResult(t, handler, "query_alerts", map[string]any{"labels": "service=checkout,env", "query": "cpu"}))
+
+	assert.Len(t, api.requests("/alert/list"), 2, "the whole window is scanned")
+	var payload struct {
+		Alerts []map[string]any `json:"alerts"`
+		Total  int              `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &payload))
+	assert.Equal(t, 2, payload.Total)
+	require.Len(t, payload.Alerts, 2)
+	assert.Equal(t, "x1", payload.Alerts[0]["alert_id"])
+	assert.Equal(t, "x4", payload.Alerts[1]["alert_id"])
+
+	// Paging runs over the matches.
+	out = resultText(t, callResult(t, handler, "query_alerts", map[string]any{"labels": "service=checkout", "limit": 1.0, "page": 2.0}))
+	require.NoError(t, json.Unmarshal([]byte(out), &payload))
+	require.Len(t, payload.Alerts, 1)
+	assert.Equal(t, "x4", payload.Alerts[0]["alert_id"])
+
+	res := callResult(t, handler, "query_alerts", map[string]any{"labels": "=prod"})
+	assert.True(t, res.IsError)
+}
+
+func TestFindIncidentsForAlertGroupsByIncident(t *testing.T) {
+	t.Parallel()
+
+	api := newFakeAPI(t, map[string]fakeRoute{
+		"/alert/list-by-ids": func(req map[string]any) any {
+			assert.Equal(t, []any{"x1", "x2", "x3", "x9"}, req["alert_ids"])
+			return map[string]any{"items": []any{
+				map[string]any{"alert_id": "x1", "incident": map[string]any{"incident_id": "i1"}},
+				map[string]any{"alert_id": "x2"},
+				map[string]any{"alert_id": "x3", "incident": map[string]any{"incident_id": "i1"}},
+			}}
+		},
+		"/alert/list": reply(map[string]any{"items": []any{
+			map[string]any{"alert_id": "x1", "labels": map[string]any{"host": "db-07"}, "incident": map[string]any{"incident_id": "i1"}},
+			map[string]any{"alert_id": "x5", "labels": map[string]any{"host": "db-08"}, "incident": map[string]any{"incident_id": "i2"}},
+		}}),
+		"/incident/list-by-ids": func(req map[string]any) any {
+			assert.Equal(t, []any{"i1"}, req["incident_ids"])
+			return map[string]any{"items": []any{map[string]any{
+				"incident_id": "i1", "progress": "Processing", "description": "dropped",
+				"responders": []any{map[string]any{"person_id": 7, "person_name": "Alice", "email": "dropped"}},
+			}}}
+		},
+	})
+	_, handler := FindIncidentsForAlert(newTestClients(t, api.URL), translations.NullTranslationHelper)
+
+	out := resultText(t, callResult(t, handler, "find_incidents_for_alert", map[string]any{"alert_ids": "x1,x2,x3,x9"}))
+	var payload struct {
+		Incidents []map[string]any `json:"incidents"`
+		Orphans   []string         `json:"alerts_without_incident"`
+		NotFound  []string         `json:"alerts_not_found"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &payload))
+	require.Len(t, payload.Incidents, 1)
+	inc := payload.Incidents[0]
+	assert.Equal(t, "Processing", inc["progress"])
+	assert.Equal(t, []any{"x1", "x3"}, inc["matched_alert_ids"])
+	assert.NotContains(t, inc, "description")
+	responder := inc["responders"].([]any)[0].(map[string]any)
+	assert.Equal(t, "Alice", responder["person_name"])
+	assert.NotContains(t, responder, "email")
+	assert.Equal(t, []string{"x2"}, payload.Orphans)
+	assert.Equal(t, []string{"x9"}, payload.NotFound)
+
+	out = resultText(t, callResult(t, handler, "find_incidents_for_alert", map[string]any{"labels": "host=db-07", "since": "1h"}))
+	payload.Incidents, payload.Orphans, payload.NotFound = nil, nil, nil
+	require.NoError(t, json.Unmarshal([]byte(out), &payload))
+	require.Len(t, payload.Incidents, 1)
+	assert.Equal(t, []any{"x1"}, payload.Incidents[0]["matched_alert_ids"])
+	assert.Empty(t, payload.Orphans)
+	assert.Empty(t, payload.NotFound)
+
+	assert.True(t, callResult(t, handler, "find_incidents_for_alert", map[string]any{}).IsError)
+}
+
+func TestSendAlertEventResolvesAlertAndIncident(t *testing.T) {
+	t.Parallel()
+
+	api := newFakeAPI(t, map[string]fakeRoute{
+		"/event/push/alert/standard": reply(fakeResponse{Status: http.StatusOK, Body: map[string]any{"request_id": "req-1"}}),
+		"/alert/list": func(map[string]any) any {
+			return map[string]any{"items": []any{map[string]any{
+				"alert_id": "x1", "alert_status": "Critical", "channel_id": 3, "channel_name": "payments",
+				"last_time": time.Now().Unix(),
+				"incident":  map[string]any{"incident_id": "i1", "progress": "Triggered"},
+			}}}
+		},
+	})
+	tool, handler := SendAlertEvent(newTestClients(t, api.URL), translations.NullTranslationHelper)
+	require.False(t, *tool.Annotations.ReadOnlyHint)
+
+	out := resultText(t, callResult(t, handler, "send_alert_event", map[string]any{
+		"integration_key": "push-key",
+		"title":           "Checkout latency test",
+		"severity":        "Critical",
+		"labels":          "service=checkout",
+	}))
+
+	assert.Equal(t, "push-key", api.query("/event/push/alert/standard").Get("integration_key"))
+	require.Len(t, api.requests("/event/push/alert/standard"), 1)
+	event := api.requests("/event/push/alert/standard")[0]
+	require.NotEmpty(t, api.requests("/alert/list"))
+	lookup := api.requests("/alert/list")[0]
+	assert.Equal(t, "Checkout latency test", event["title_rule"])
+	assert.Equal(t, "Critical", event["event_status"])
+	assert.Equal(t, map[string]any{"service": "checkout"}, event["labels"])
+	assert.Equal(t, []any{event["alert_key"]}, lookup["alert_keys"])
+	assert.Equal(t, true, lookup["by_updated_at"])
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &payload))
+	assert.Equal(t, "x1", payload["alert_id"])
+	assert.Equal(t, "i1", payload["incident"].(map[string]any)["incident_id"])
+	assert.NotContains(t, payload, "hint")
+}
+
+func TestSendAlertEventWaitsForTheEventsUpdate(t *testing.T) {
+	t.Parallel()
+
+	// The server's clock runs behind ours, and the alert was last updated by
+	// an earlier event, which already grouped it into an incident.
+	serverNow := time.Now().Add(-10 * time.Second).Unix()
+	var mu sync.Mutex
+	pushed, lookupsSincePush := false, 0
+	api := newFakeAPI(t, map[string]fakeRoute{
+		"/event/push/alert/standard": func(map[string]any) any {
+			mu.Lock()
+			defer mu.Unlock()
+			pushed = true
+			return fakeResponse{Status: http.StatusOK, Body: map[string]any{"request_id": "req-1"}}
+		},
+		"/alert/list": func(map[string]any) any {
+			mu.Lock()
+			defer mu.Unlock()
+			lastTime, progress := serverNow-30, "Processing"
+			if pushed {
+				// The event shows up on the second lookup after the push.
+				if lookupsSincePush++; lookupsSincePush > 1 {
+					lastTime, progress = serverNow, "Triggered"
+				}
+			}
+			return map[string]any{"items": []any{map[string]any{
+				"alert_id": "x1", "alert_status": "Critical", "last_time": lastTime,
+				"incident": map[string]any{"incident_id": "i1", "progress": progress},
+			}}}
+		},
+	})
+	_, handler := SendAlertEvent(newTestClients(t, api.URL), translations.NullTranslationHelper)
+
+	out := resultText(t, callResult(t, handler, "send_alert_event", map[string]any{
+		"integration_key": "push-key",
+		"title":           "Checkout latency test",
+		"alert_key":       "checkout-latency",
+	}))
+
+	assert.Len(t, api.requests("/alert/list"), 3, "one lookup before the push, two after")
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &payload))
+	assert.Equal(t, "Triggered", payload["incident"].(map[string]any)["progress"])
+	assert.NotContains(t, payload, "hint")
+}
+
+func TestSendAlertEventRecoveryNeedsAlertKey(t *testing.T) {
+	t.Parallel()
+
+	_, handler := SendAlertEvent(func(ctx context.Context) (context.Context, *Clients, error) {
+		return ctx, &Clients{}, nil
+	}, translations.NullTranslationHelper)
+	res := callResult(t, handler, "send_alert_event", map[string]any{"integration_key": "k", "title": "x", "event_status": "recovered"})
+	assert.True(t, res.IsError)
+}
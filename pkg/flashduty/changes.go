@@ -3,6 +3,11 @@ package flashduty
 import (
 	"context"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	flashduty "github.com/flashcatcloud/go-flashduty"
@@ -15,6 +20,10 @@ import (
 
 const queryChangesDescription = `Query change records (deployments, configurations). Useful for correlating changes with incidents.`
 
+const createChangeDescription = `Register a change (deployment, configuration change, rollback...) so it shows up in query_changes and correlate_changes. Pushed through a change-event integration, which is the only target: integration_key is that integration's push key, and there is no channel argument because the integration's channel routing decides which channels see the change. To reach a channel, use an integration routed to it. Re-sending with the same change_key updates that change, e.g. to move it from Processing to Done.`
+
+const correlateChangesDescription = `Find the changes most likely to have caused an incident. Looks at changes in the incident's channel during the lookback window ending when the incident started, and ranks them by how close to the start they happened and how many labels they share with the incident. Each candidate carries a score (0-1) and a reason.`
+
 // QueryChanges creates a tool to query change records
 func QueryChanges(getClient GetFlashdutyClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("query_changes",
@@ -30,6 +39,7 @@ func QueryChanges(getClient GetFlashdutyClientFn, t translations.TranslationHelp
 			mcp.WithString("type", mcp.Description("Filter by change type.")),
 			mcp.WithNumber("limit", mcp.Description(LimitDescription), mcp.DefaultNumber(20), mcp.Min(1), mcp.Max(100)),
 			mcp.WithNumber("page", mcp.Description(PageDescription), mcp.DefaultNumber(1), mcp.Min(1)),
+			withFieldsParam(),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			ctx, client, err := getClient(ctx)
 			if err != nil {
@@ -107,15 +117,318 @@ func QueryChanges(getClient GetFlashdutyClientFn, t translations.TranslationHelp
 						filtered = append(filtered, ch)
 					}
 				}
-				return MarshalResult(map[string]any{
-					"changes": filtered,
+				return MarshalResult(ctx, map[string]any{
+					"changes": optionalProjection(request).apply(filtered),
 					"total":   len(filtered),
 				}), nil
 			}
 
-			return MarshalResult(addPageHint(map[string]any{
-				"changes": changes,
+			return MarshalResult(ctx, addPageHint(map[string]any{
+				"changes": optionalProjection(request).apply(changes),
 				"total":   resp.Total,
 			}, len(changes), int(resp.Total), page, limit)), nil
 		}
 }
+
+// defaultChangeLookback is how far before an incident started the
+// change-aware tools look for changes when the caller gives no lookback.
+const defaultChangeLookback = 6 * time.Hour
+
+// optionalLookback parses the `lookback` argument, defaulting to
+// defaultChangeLookback.
+func optionalLookback(r mcp.CallToolRequest) (time.Duration, error) {
+	s, _ := OptionalParam[string](r, "lookback")
+	if s == "" {
+		return defaultChangeLookback, nil
+	}
+	d, err := timeutil.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid lookback: %w", err)
+	}
+	if d > MaxTimeWindow {
+		return 0, fmt.Errorf("lookback of %s exceeds the 31-day max", d)
+	}
+	return d, nil
+}
+
+// precedingChanges lists the changes in channelID during the lookback window
+// ending at start, newest first so that a truncated page keeps the changes
+// closest to the incident.
+func precedingChanges(ctx context.Context, client *Clients, channelID, start int64, lookback time.Duration) (*flashduty.ListChangeResponse, error) {
+	if channelID == 0 {
+		return nil, fmt.Errorf("the incident belongs to no channel, so its changes cannot be scoped")
+	}
+	req := &flashduty.ListChangeRequest{
+		ChannelIDs: []int64{channelID},
+		StartTime:  start - int64(lookback/time.Second),
+		EndTime:    start,
+	}
+	req.Limit = 100
+	out, _, err := client.New.Changes.List(ctx, req)
+	return out, err
+}
+
+// CorrelateChanges creates a tool that ranks the changes preceding an incident
+func CorrelateChanges(getClient GetFlashdutyClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("correlate_changes",
+			mcp.WithDescription(t("TOOL_CORRELATE_CHANGES_DESCRIPTION", correlateChangesDescription)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CORRELATE_CHANGES_USER_TITLE", "Correlate changes with an incident"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("incident_id", mcp.Required(), mcp.Description("The incident to find candidate changes for.")),
+			mcp.WithString("lookback", mcp.Description("How far before the incident started to look, as a duration (\"30m\", \"2h\", \"1d\"). Default 6h, max 31d.")),
+			mcp.WithNumber("limit", mcp.Description("Maximum number of candidates to return."), mcp.DefaultNumber(10), mcp.Min(1), mcp.Max(100)),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Flashduty client: %w", err)
+			}
+
+			incidentID, err := RequiredParam[string](request, "incident_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			lookback, err := optionalLookback(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := OptionalInt(request, "limit")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if limit <= 0 {
+				limit = 10
+			}
+
+			inc, _, err := client.New.Incidents.Info(ctx, &flashduty.IncidentInfoRequest{IncidentID: incidentID})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve incident: %v", err)), nil
+			}
+			if inc.ChannelID == 0 {
+				return mcp.NewToolResultError("the incident belongs to no channel, so its changes cannot be scoped; use query_changes with explicit channel_ids"), nil
+			}
+
+			resp, err := precedingChanges(ctx, client, inc.ChannelID, inc.StartTime.Unix(), lookback)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve changes: %v", err)), nil
+			}
+
+			candidates := make([]changeCandidate, 0, len(resp.Items))
+			for _, c := range resp.Items {
+				candidates = append(candidates, scoreChange(c, inc, lookback))
+			}
+			sort.SliceStable(candidates, func(i, j int) bool {
+				return candidates[i].Score > candidates[j].Score
+			})
+			if len(candidates) > limit {
+				candidates = candidates[:limit]
+			}
+
+			result := map[string]any{
+				"incident": map[string]any{
+					"incident_id":  inc.IncidentID,
+					"title":        inc.Title,
+					"channel_id":   inc.ChannelID,
+					"channel_name": inc.ChannelName,
+					"start_time":   inc.StartTime,
+					"labels":       inc.Labels,
+				},
+				"window":     map[string]any{"since": inc.StartTime.Unix() - int64(lookback/time.Second), "until": inc.StartTime.Unix()},
+				"candidates": candidates,
+				"total":      resp.Total,
+			}
+			if int(resp.Total) > len(resp.Items) {
+				result["truncated"] = true
+				result["hint"] = fmt.Sprintf("Only the %d latest of %d changes in the window were ranked. Shorten `lookback` if the cause may be older.", len(resp.Items), resp.Total)
+			}
+			return MarshalResult(ctx, result), nil
+		}
+}
+
+// changeCandidate is one ranked change in a correlate_changes result.
+type changeCandidate struct {
+	ChangeID     string              `json:"change_id"`
+	Title        string              `json:"title"`
+	ChangeStatus string              `json:"change_status,omitempty"`
+	StartTime    flashduty.Timestamp `json:"start_time"`
+	EndTime      flashduty.Timestamp `json:"end_time,omitzero"`
+	Link         string              `json:"link,omitempty"`
+	Labels       map[string]string   `json:"labels,omitempty"`
+	Score        float64             `json:"score"`
+	Reason       string              `json:"reason"`
+}
+
+// scoreChange rates how likely c is to have caused inc. Proximity falls off
+// linearly from 1 (started as the incident did) to 0 (started at the edge of
+// the lookback window). Label overlap counts shared key=value pairs in full
+// and values shared under a different key (app=checkout vs service=checkout,
+// as change and alert integrations rarely agree on keys) at half weight,
+// relative to the incident's label count. The two are weighted equally; an
+// incident with no labels is ranked on proximity alone.
+func scoreChange(c flashduty.ChangeItem, inc *flashduty.IncidentInfo, lookback time.Duration) changeCandidate {
+	gap := time.Duration(inc.StartTime.Unix()-c.StartTime.Unix()) * time.Second
+	proximity := math.Max(0, 1-float64(gap)/float64(lookback))
+
+	incidentValues := map[string]bool{}
+	for _, v := range inc.Labels {
+		incidentValues[v] = true
+	}
+	var exact, valueOnly []string
+	for _, k := range slices.Sorted(maps.Keys(c.Labels)) {
+		v := c.Labels[k]
+		switch iv, ok := inc.Labels[k]; {
+		case ok && iv == v:
+			exact = append(exact, k+"="+v)
+		case v != "" && incidentValues[v]:
+			valueOnly = append(valueOnly, k+"="+v)
+		}
+	}
+
+	score := proximity
+	if len(inc.Labels) > 0 {
+		overlap := math.Min(1, (float64(len(exact))+0.5*float64(len(valueOnly)))/float64(len(inc.Labels)))
+		score = 0.5*proximity + 0.5*overlap
+	}
+
+	reasons := []string{fmt.Sprintf("started %s before the incident", gap.Round(time.Minute))}
+	if c.EndTime.IsZero() || c.EndTime.Unix() > inc.StartTime.Unix() {
+		reasons = append(reasons, "was still in progress when it started")
+	}
+	if len(exact) > 0 {
+		reasons = append(reasons, "shares labels "+strings.Join(exact, ", "))
+	}
+	if len(valueOnly) > 0 {
+		reasons = append(reasons, "shares label values "+strings.Join(valueOnly, ", "))
+	}
+	if len(inc.Labels) > 0 && len(exact)+len(valueOnly) == 0 {
+		reasons = append(reasons, "shares no labels with the incident")
+	}
+
+	return changeCandidate{
+		ChangeID:     c.ChangeID,
+		Title:        c.Title,
+		ChangeStatus: c.ChangeStatus,
+		StartTime:    c.StartTime,
+		EndTime:      c.EndTime,
+		Link:         c.Link,
+		Labels:       c.Labels,
+		Score:        math.Round(score*100) / 100,
+		Reason:       strings.Join(reasons, "; "),
+	}
+}
+
+// changeStatuses are the states the change-event integration accepts.
+var changeStatuses = []string{"Planned", "Ready", "Processing", "Canceled", "Done"}
+
+// CreateChange creates a tool that registers a change through a change-event
+// integration
+func CreateChange(getClient GetFlashdutyClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_change",
+			mcp.WithDescription(t("TOOL_CREATE_CHANGE_DESCRIPTION", createChangeDescription)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_CHANGE_USER_TITLE", "Create change"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("integration_key", mcp.Required(), mcp.Description("Push key of the change-event integration to send the change to. The integration, not this call, decides which channels receive the change.")),
+			mcp.WithString("title", mcp.Required(), mcp.Description("Change title, e.g. \"Deploy checkout v2.3.1\".")),
+			mcp.WithString("type", mcp.Description("Change type, e.g. deploy, config, rollback, feature_flag. Recorded as the `type` label so query_changes(type=...) finds it.")),
+			mcp.WithString("change_key", mcp.Description("Stable key identifying the change across updates. Generated when omitted; reuse the returned key to update the change.")),
+			mcp.WithString("status", mcp.Description("Change status. Default: Done when end_time is given, otherwise Processing."), mcp.Enum(changeStatuses...)),
+			mcp.WithString("description", mcp.Description("What changed and why. Markdown is accepted.")),
+			mcp.WithString("labels", mcp.Description("Comma-separated key=value labels, e.g. \"service=checkout,env=prod,version=2.3.1\". Labels shared with alerts drive correlate_changes.")),
+			mcp.WithString("link", mcp.Description("URL of the deploy job, pull request or change ticket.")),
+			mcp.WithString("start_time", mcp.Description("When the change started. Same formats as `since` on the query tools; defaults to now.")),
+			mcp.WithString("end_time", mcp.Description("When the change finished, if it has. Same formats as start_time.")),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Flashduty client: %w", err)
+			}
+
+			args := request.GetArguments()
+			integrationKey, err := RequiredParam[string](request, "integration_key")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := RequiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			changeType, _ := OptionalParam[string](request, "type")
+			changeKey, _ := OptionalParam[string](request, "change_key")
+			status, _ := OptionalParam[string](request, "status")
+			description, _ := OptionalParam[string](request, "description")
+			labelsStr, _ := OptionalParam[string](request, "labels")
+			link, _ := OptionalParam[string](request, "link")
+
+			labels, err := parseLabels(labelsStr)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if changeType != "" {
+				if labels == nil {
+					labels = map[string]string{}
+				}
+				labels["type"] = changeType
+			}
+
+			startTime, err := timeutil.ParseAny(args["start_time"])
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid start_time: %v", err)), nil
+			}
+			if startTime == 0 {
+				startTime = time.Now().Unix()
+			}
+			endTime, err := timeutil.ParseAny(args["end_time"])
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid end_time: %v", err)), nil
+			}
+			if endTime != 0 && endTime < startTime {
+				return mcp.NewToolResultError(fmt.Sprintf("end_time (%d) is before start_time (%d)", endTime, startTime)), nil
+			}
+
+			switch {
+			case status == "" && endTime != 0:
+				status = "Done"
+			case status == "":
+				status = "Processing"
+			case !slices.Contains(changeStatuses, status):
+				return mcp.NewToolResultError(fmt.Sprintf("invalid status %q: use one of %s", status, strings.Join(changeStatuses, ", "))), nil
+			}
+			if changeKey == "" {
+				changeKey = fmt.Sprintf("mcp-%d", time.Now().UnixNano())
+			}
+
+			event := map[string]any{
+				"title":         title,
+				"change_key":    changeKey,
+				"change_status": status,
+				"start_time":    startTime,
+			}
+			if description != "" {
+				event["description"] = description
+			}
+			if len(labels) > 0 {
+				event["labels"] = labels
+			}
+			if link != "" {
+				event["link"] = link
+			}
+			if endTime != 0 {
+				event["end_time"] = endTime
+			}
+
+			out, err := pushEvent(ctx, client, "/event/push/change/standard", integrationKey, event)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Unable to push change: %v", err)), nil
+			}
+
+			return MarshalResult(ctx, map[string]any{
+				"change_key":    changeKey,
+				"change_status": status,
+				"request_id":    out.RequestID,
+				"hint":          "Changes are processed asynchronously; they appear in query_changes within a few seconds.",
+			}), nil
+		}
+}
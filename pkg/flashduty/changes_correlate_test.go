@@ -0,0 +1,53 @@
+package flashduty
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
+)
+
+func TestCorrelateChangesRanksByProximityAndLabels(t *testing.T) {
+	t.Parallel()
+
+	const start = 1700000000
+	api := newFakeAPI(t, map[string]fakeRoute{
+		"/incident/info": reply(map[string]any{
+			"incident_id": "a1", "channel_id": 3, "start_time": start,
+			"labels": map[string]any{"service": "checkout", "env": "prod"},
+		}),
+		"/change/list": func(req map[string]any) any {
+			assert.Equal(t, []any{3.0}, req["channel_ids"])
+			assert.Equal(t, float64(start-3600), req["start_time"])
+			assert.Equal(t, float64(start), req["end_time"])
+			return map[string]any{"total": 3, "items": []any{
+				// Closest, but unrelated.
+				map[string]any{"change_id": "c1", "title": "Bump search", "start_time": start - 120, "end_time": start - 60, "labels": map[string]any{"service": "search"}},
+				// Same service under another key, still rolling out.
+				map[string]any{"change_id": "c2", "title": "Deploy checkout", "start_time": start - 600, "labels": map[string]any{"app": "checkout", "env": "prod"}},
+				// Old and unrelated.
+				map[string]any{"change_id": "c3", "title": "Rotate certs", "start_time": start - 3000, "end_time": start - 2900},
+			}}
+		},
+	})
+	_, handler := CorrelateChanges(newTestClients(t, api.URL), translations.NullTranslationHelper)
+
+	res := callResult(t, handler, "correlate_changes", map[string]any{"incident_id": "a1", "lookback": "1h"})
+
+	var payload struct {
+		Candidates []changeCandidate `json:"candidates"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resultText(t, res)), &payload))
+	require.Len(t, payload.Candidates, 3)
+
+	assert.Equal(t, []string{"c2", "c1", "c3"}, []string{
+		payload.Candidates[0].ChangeID, payload.Candidates[1].ChangeID, payload.Candidates[2].ChangeID,
+	})
+	top := payload.Candidates[0]
+	assert.Equal(t, 0.79, top.Score)
+	assert.Equal(t, "started 10m0s before the incident; was still in progress when it started; shares labels env=prod; shares label values app=checkout", top.Reason)
+	assert.Contains(t, payload.Candidates[1].Reason, "shares no labels with the incident")
+}
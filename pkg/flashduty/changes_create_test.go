@@ -0,0 +1,71 @@
+package flashduty
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
+)
+
+func TestCreateChangePushesStandardEvent(t *testing.T) {
+	t.Parallel()
+
+	const path = "/event/push/change/standard"
+	api := newFakeAPI(t, map[string]fakeRoute{
+		path: reply(fakeResponse{Status: http.StatusOK, Body: map[string]any{"request_id": "req-1"}}),
+	})
+	tool, handler := CreateChange(newTestClients(t, api.URL), translations.NullTranslationHelper)
+	require.False(t, *tool.Annotations.ReadOnlyHint)
+
+	out := resultText(t, callResult(t, handler, "create_change", map[string]any{
+		"integration_key": "push-key",
+		"title":           "Deploy checkout v2",
+		"type":            "deploy",
+		"labels":          "service=checkout, env=prod",
+		"start_time":      "1700000000",
+		"end_time":        "1700000600",
+	}))
+
+	require.Len(t, api.requests(path), 1)
+	event := api.requests(path)[0]
+	assert.Equal(t, "push-key", api.query(path).Get("integration_key"))
+	assert.Empty(t, api.query(path).Get("app_key"), "pushes authenticate with the integration key only")
+	assert.Equal(t, "Deploy checkout v2", event["title"])
+	assert.Equal(t, "Done", event["change_status"], "a finished change defaults to Done")
+	assert.Equal(t, 1700000000.0, event["start_time"])
+	assert.Equal(t, map[string]any{"service": "checkout", "env": "prod", "type": "deploy"}, event["labels"])
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &payload))
+	assert.Equal(t, event["change_key"], payload["change_key"])
+	assert.Equal(t, "req-1", payload["request_id"])
+
+	for _, args := range []map[string]any{
+		{"integration_key": "k", "title": "x", "labels": "service"},
+		{"integration_key": "k", "title": "x", "status": "Shipped"},
+		{"integration_key": "k", "title": "x", "start_time": "1700000600", "end_time": "1700000000"},
+	} {
+		assert.True(t, callResult(t, handler, "create_change", args).IsError, "args %v", args)
+	}
+}
+
+func TestCreateChangeReportsPushErrors(t *testing.T) {
+	t.Parallel()
+
+	api := newFakeAPI(t, map[string]fakeRoute{
+		"/event/push/change/standard": reply(fakeResponse{
+			Status: http.StatusBadRequest,
+			Body:   map[string]any{"error": map[string]any{"code": "InvalidParameter", "message": "integration not found"}},
+		}),
+	})
+	_, handler := CreateChange(newTestClients(t, api.URL), translations.NullTranslationHelper)
+
+	res := callResult(t, handler, "create_change", map[string]any{"integration_key": "bad", "title": "x"})
+	require.True(t, res.IsError)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "integration not found")
+}
@@ -25,6 +25,8 @@ func QueryChannels(getClient GetFlashdutyClientFn, t translations.TranslationHel
 			mcp.WithString("name", mcp.Description("Search by channel name (case-insensitive substring match).")),
 			mcp.WithNumber("limit", mcp.Description(LimitDescription), mcp.DefaultNumber(20), mcp.Min(1), mcp.Max(100)),
 			mcp.WithNumber("page", mcp.Description(PageDescription), mcp.DefaultNumber(1), mcp.Min(1)),
+			withCacheParam(),
+			withFieldsParam(),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			ctx, client, err := getClient(ctx)
 			if err != nil {
@@ -62,14 +64,14 @@ func QueryChannels(getClient GetFlashdutyClientFn, t translations.TranslationHel
 				req.ChannelIDs = int64IDs
 			}
 
-			out, _, err := client.New.Channels.ChannelList(ctx, req)
+			out, err := channelList(ctx, client, cacheBypassed(request), req)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve channels: %v", err)), nil
 			}
 
 			total := int(out.Total)
-			return MarshalResult(addPageHint(map[string]any{
-				"channels": out.Items,
+			return MarshalResult(ctx, addPageHint(map[string]any{
+				"channels": optionalProjection(request).apply(out.Items),
 				"total":    total,
 			}, len(out.Items), total, page, limit)), nil
 		}
@@ -86,6 +88,8 @@ func QueryEscalationRules(getClient GetFlashdutyClientFn, t translations.Transla
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithNumber("channel_id", mcp.Required(), mcp.Description("Channel ID to query escalation rules for.")),
+			withFieldsParam(),
+			withResolveNamesParam(),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			ctx, client, err := getClient(ctx)
 			if err != nil {
@@ -106,8 +110,12 @@ func QueryEscalationRules(getClient GetFlashdutyClientFn, t translations.Transla
 
 			// go-flashduty returns the full rule set without a separate total.
 			total := len(out.Items)
-			return MarshalResult(addTruncationHint(map[string]any{
-				"rules": out.Items,
+			rules := optionalProjection(request).apply(out.Items)
+			if optionalResolveNames(request) {
+				rules = resolveNames(ctx, client, rules)
+			}
+			return MarshalResult(ctx, addTruncationHint(map[string]any{
+				"rules": rules,
 				"total": total,
 			}, total, total)), nil
 		}
@@ -2,6 +2,7 @@ package flashduty
 
 import (
 	"context"
+	"net/http"
 
 	flashduty "github.com/flashcatcloud/go-flashduty"
 )
@@ -11,6 +12,22 @@ import (
 // New is the typed go-flashduty client and backs every tool.
 type Clients struct {
 	New *flashduty.Client
+
+	// Cache holds slow-changing reference data (members, teams, channels,
+	// fields) fetched with this APP key. Lookups always reach the API when
+	// it is nil.
+	Cache ReferenceCache
+
+	// Push sends integration events (alert and change pushes), which are
+	// authenticated by an integration key rather than the APP key and so sit
+	// outside the SDK. http.DefaultClient is used when it is nil.
+	Push *http.Client
+}
+
+// ReferenceCache memoizes reference-data lookups by key. On a miss, or when
+// refresh is set, it calls load and stores the result.
+type ReferenceCache interface {
+	Get(ctx context.Context, key string, refresh bool, load func() (any, error)) (any, error)
 }
 
 // GetFlashdutyClientFn returns the Flashduty clients for the current request.
@@ -0,0 +1,106 @@
+package flashduty
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// fanOutConcurrency caps the API calls one tool invocation keeps in flight
+// when it looks up several IDs. It bounds the burst a single agent request can
+// send at the Open API's rate limit.
+const fanOutConcurrency = 5
+
+// fanOutResult is the outcome of fn for one ID.
+type fanOutResult[T any] struct {
+	ID    string
+	Value T
+	Err   error
+}
+
+// fanOut calls fn for each ID on a bounded worker pool and returns one result
+// per ID in input order. A failing ID does not cancel the others: callers
+// report it as a per-ID `error` entry so the results already fetched are not
+// thrown away.
+//
+// When the client attached a progress token to the tool call, a
+// notifications/progress message is sent as each ID completes.
+func fanOut[T any](ctx context.Context, request mcp.CallToolRequest, ids []string, fn func(ctx context.Context, id string) (T, error)) []fanOutResult[T] {
+	results := make([]fanOutResult[T], len(ids))
+	progress := newProgressReporter(ctx, request, len(ids))
+
+	sem := make(chan struct{}, fanOutConcurrency)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			value, err := fn(ctx, id)
+			results[i] = fanOutResult[T]{ID: id, Value: value, Err: err}
+			progress.done(id)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// fanOutErrorEntry is the result entry for an ID whose lookup failed.
+func fanOutErrorEntry(idKey, id string, err error) map[string]any {
+	return map[string]any{idKey: id, "error": err.Error()}
+}
+
+// allFailed reports whether every lookup failed, in which case handlers return
+// a plain error result: per-ID entries would only repeat the same failure
+// (typically auth or connectivity) N times.
+func allFailed[T any](results []fanOutResult[T]) bool {
+	for _, r := range results {
+		if r.Err == nil {
+			return false
+		}
+	}
+	return len(results) > 0
+}
+
+// progressReporter sends MCP progress notifications for a tool call. It is a
+// no-op when the client sent no progress token.
+type progressReporter struct {
+	ctx   context.Context
+	srv   *server.MCPServer
+	token mcp.ProgressToken
+	total int
+
+	mu        sync.Mutex
+	completed int
+}
+
+func newProgressReporter(ctx context.Context, request mcp.CallToolRequest, total int) *progressReporter {
+	p := &progressReporter{ctx: ctx, total: total}
+	if meta := request.Params.Meta; meta != nil && meta.ProgressToken != nil {
+		p.token = meta.ProgressToken
+		p.srv = server.ServerFromContext(ctx)
+	}
+	return p
+}
+
+// done records that id finished and notifies the client. Notifications are
+// sent under the lock so progress values reach the client in increasing order.
+func (p *progressReporter) done(id string) {
+	if p.srv == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.completed++
+	_ = p.srv.SendNotificationToClient(p.ctx, string(mcp.MethodNotificationProgress), map[string]any{
+		"progressToken": p.token,
+		"progress":      p.completed,
+		"total":         p.total,
+		"message":       fmt.Sprintf("Fetched %s (%d/%d)", id, p.completed, p.total),
+	})
+}
@@ -0,0 +1,172 @@
+package flashduty
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
+)
+
+func TestFanOutKeepsOrderAndBoundsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	ids := make([]string, 20)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("id-%02d", i)
+	}
+
+	var inFlight, peak atomic.Int32
+	results := fanOut(context.Background(), mcp.CallToolRequest{}, ids, func(_ context.Context, id string) (string, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if id == "id-07" {
+			return "", errors.New("boom")
+		}
+		return "value-" + id, nil
+	})
+
+	if got := peak.Load(); got > fanOutConcurrency {
+		t.Errorf("peak concurrency = %d, want <= %d", got, fanOutConcurrency)
+	}
+	for i, r := range results {
+		if r.ID != ids[i] {
+			t.Fatalf("results[%d].ID = %s, want %s", i, r.ID, ids[i])
+		}
+		if r.ID == "id-07" {
+			if r.Err == nil {
+				t.Errorf("expected an error for %s", r.ID)
+			}
+			continue
+		}
+		if r.Err != nil || r.Value != "value-"+r.ID {
+			t.Errorf("results[%d] = %+v", i, r)
+		}
+	}
+	if allFailed(results) {
+		t.Error("allFailed reported true with successes present")
+	}
+}
+
+// TestQueryIncidentTimelineReturnsPartialResults verifies a failing incident
+// becomes an error entry instead of discarding the other incidents' timelines.
+func TestQueryIncidentTimelineReturnsPartialResults(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		if body["incident_id"] == "missing" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"error": map[string]any{"code": "InvalidParameter", "message": "incident not found"},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"items": []any{map[string]any{"type": "i_new"}}},
+		})
+	}))
+	defer ts.Close()
+
+	_, handler := QueryIncidentTimeline(newTestClients(t, ts.URL), translations.NullTranslationHelper)
+	result, err := handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name:      "query_incident_timeline",
+			Arguments: map[string]any{"incident_ids": "a,missing,b"},
+		},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("expected a partial success, got err=%v result=%+v", err, result)
+	}
+
+	entries := decodeResult(t, result)["results"].([]any)
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	for i, want := range []string{"a", "missing", "b"} {
+		entry := entries[i].(map[string]any)
+		if entry["incident_id"] != want {
+			t.Errorf("entries[%d].incident_id = %v, want %s", i, entry["incident_id"], want)
+		}
+		_, hasErr := entry["error"]
+		if hasErr != (want == "missing") {
+			t.Errorf("entries[%d] = %v", i, entry)
+		}
+	}
+
+	result, _ = handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name:      "query_incident_timeline",
+			Arguments: map[string]any{"incident_ids": "missing"},
+		},
+	})
+	if !result.IsError {
+		t.Error("expected an error result when every incident fails")
+	}
+}
+
+// progressSession is a minimal initialized session that captures notifications.
+type progressSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *progressSession) SessionID() string { return "progress-session" }
+func (s *progressSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+func (s *progressSession) Initialize()       {}
+func (s *progressSession) Initialized() bool { return true }
+
+func TestFanOutSendsProgressNotifications(t *testing.T) {
+	t.Parallel()
+
+	mcpServer := server.NewMCPServer("test", "test")
+	mcpServer.AddTool(mcp.NewTool("multi"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		fanOut(ctx, request, []string{"a", "b", "c"}, func(context.Context, string) (bool, error) { return true, nil })
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	session := &progressSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	if err := mcpServer.RegisterSession(context.Background(), session); err != nil {
+		t.Fatalf("register session: %v", err)
+	}
+	ctx := mcpServer.WithContext(context.Background(), session)
+
+	mcpServer.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"multi"}}`))
+	if len(session.notifications) != 0 {
+		t.Fatalf("progress sent without a progress token")
+	}
+
+	mcpServer.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"multi","_meta":{"progressToken":"tok"}}}`))
+	if len(session.notifications) != 3 {
+		t.Fatalf("got %d progress notifications, want 3", len(session.notifications))
+	}
+	for want := 1; want <= 3; want++ {
+		n := <-session.notifications
+		if n.Method != string(mcp.MethodNotificationProgress) {
+			t.Fatalf("method = %s", n.Method)
+		}
+		fields := n.Params.AdditionalFields
+		if fields["progressToken"] != "tok" || fields["progress"] != want || fields["total"] != 3 {
+			t.Errorf("notification %d = %v", want, fields)
+		}
+	}
+}
@@ -21,8 +21,10 @@ func QueryFields(getClient GetFlashdutyClientFn, t translations.TranslationHelpe
 				Title:        t("TOOL_QUERY_FIELDS_USER_TITLE", "Query fields"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
-			mcp.WithString("field_ids", mcp.Description("Comma-separated field IDs for direct lookup.")),
+			mcp.WithString("field_ids", mcp.Description("Comma-separated field IDs for direct lookup. An ID that cannot be loaded appears in `fields` as a {field_id, error} entry.")),
 			mcp.WithString("field_name", mcp.Description("Search by exact field name. Field names must match pattern: ^[a-z][a-z0-9_]*$")),
+			withCacheParam(),
+			withFieldsParam(),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			ctx, client, err := getClient(ctx)
 			if err != nil {
@@ -39,31 +41,44 @@ func QueryFields(getClient GetFlashdutyClientFn, t translations.TranslationHelpe
 				if len(fieldIDs) == 0 {
 					return mcp.NewToolResultError("field_ids must contain at least one valid ID when specified"), nil
 				}
-				fields := make([]*flashduty.FieldItem, 0, len(fieldIDs))
-				for _, id := range fieldIDs {
+				results := fanOut(ctx, request, fieldIDs, func(ctx context.Context, id string) (*flashduty.FieldItem, error) {
 					item, _, err := client.New.AlertEnrichment.FieldReadInfo(ctx, &flashduty.FieldInfoRequest{FieldID: id})
-					if err != nil {
-						return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve field %s: %v", id, err)), nil
+					return item, err
+				})
+				if allFailed(results) {
+					return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve field %s: %v", results[0].ID, results[0].Err)), nil
+				}
+
+				// total counts the fields found; IDs that failed appear in
+				// `fields` as {field_id, error} entries.
+				proj := optionalProjection(request)
+				fields := make([]any, 0, len(results))
+				found := 0
+				for _, r := range results {
+					if r.Err != nil {
+						fields = append(fields, fanOutErrorEntry("field_id", r.ID, r.Err))
+						continue
 					}
-					fields = append(fields, item)
+					fields = append(fields, proj.apply(r.Value))
+					found++
 				}
-				return MarshalResult(map[string]any{
+				return MarshalResult(ctx, map[string]any{
 					"fields": fields,
-					"total":  len(fields),
+					"total":  found,
 				}), nil
 			}
 
 			// Name search maps to the Query regex filter (matches field_name and
 			// display_name); an exact name matches literally.
-			out, _, err := client.New.AlertEnrichment.FieldReadList(ctx, &flashduty.FieldListRequest{Query: fieldName})
+			out, err := fieldList(ctx, client, cacheBypassed(request), &flashduty.FieldListRequest{Query: fieldName})
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve fields: %v", err)), nil
 			}
 
 			// /field/list returns all matching fields without pagination.
 			total := len(out.Items)
-			return MarshalResult(addTruncationHint(map[string]any{
-				"fields": out.Items,
+			return MarshalResult(ctx, addTruncationHint(map[string]any{
+				"fields": optionalProjection(request).apply(out.Items),
 				"total":  total,
 			}, total, total)), nil
 		}
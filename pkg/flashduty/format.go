@@ -1,11 +1,13 @@
 package flashduty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	toon "github.com/toon-format/toon-go"
 )
 
@@ -17,13 +19,31 @@ const (
 	OutputFormatJSON OutputFormat = "json"
 	// OutputFormatTOON uses Token-Oriented Object Notation for reduced token usage
 	OutputFormatTOON OutputFormat = "toon"
+	// OutputFormatMarkdown renders list envelopes as markdown tables for
+	// clients that show results to humans
+	OutputFormatMarkdown OutputFormat = "markdown"
+	// OutputFormatCSV renders list envelopes as CSV for spreadsheet import
+	OutputFormatCSV OutputFormat = "csv"
 )
 
+// outputFormats lists every supported format, in the order advertised to
+// clients.
+var outputFormats = []string{
+	string(OutputFormatJSON),
+	string(OutputFormatTOON),
+	string(OutputFormatMarkdown),
+	string(OutputFormatCSV),
+}
+
 // ParseOutputFormat converts a string to OutputFormat, defaulting to JSON.
 func ParseOutputFormat(s string) OutputFormat {
 	switch strings.ToLower(strings.TrimSpace(s)) {
 	case "toon":
 		return OutputFormatTOON
+	case "markdown", "md":
+		return OutputFormatMarkdown
+	case "csv":
+		return OutputFormatCSV
 	default:
 		return OutputFormatJSON
 	}
@@ -37,31 +57,82 @@ func marshal(v any, format OutputFormat) ([]byte, error) {
 	switch format {
 	case OutputFormatTOON:
 		return toon.Marshal(v)
+	case OutputFormatMarkdown:
+		return marshalMarkdown(v)
+	case OutputFormatCSV:
+		return marshalCSV(v)
 	default:
 		return json.Marshal(v)
 	}
 }
 
-// outputFormat is the current output format setting (package-level for simplicity)
-var outputFormat = OutputFormatJSON
+type outputFormatKey struct{}
 
-// SetOutputFormat sets the global output format
-func SetOutputFormat(format OutputFormat) {
-	outputFormat = format
+// ContextWithOutputFormat sets the format MarshalResult uses for tool results
+// produced under ctx.
+func ContextWithOutputFormat(ctx context.Context, format OutputFormat) context.Context {
+	return context.WithValue(ctx, outputFormatKey{}, format)
 }
 
-// GetOutputFormat returns the current global output format
-func GetOutputFormat() OutputFormat {
-	return outputFormat
+// OutputFormatFromContext returns the output format set on ctx, defaulting to
+// JSON.
+func OutputFormatFromContext(ctx context.Context) OutputFormat {
+	if format, ok := ctx.Value(outputFormatKey{}).(OutputFormat); ok {
+		return format
+	}
+	return OutputFormatJSON
 }
 
-// MarshalResult serializes the given value according to the current output
-// format and returns it as a text result for an MCP tool response.
+// OutputFormatDescription is the description of the `output_format` argument
+// every tool accepts.
+const OutputFormatDescription = "Serialization of this result: json; toon for fewer tokens; markdown tables for display to humans; or csv for spreadsheets. Defaults to the format chosen for the session."
+
+// withOutputFormat adds the optional `output_format` argument to tool and
+// wraps handler so that, when given, it overrides the session's format for
+// this call.
+func withOutputFormat(tool mcp.Tool, handler server.ToolHandlerFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	if tool.InputSchema.Properties == nil {
+		tool.InputSchema.Properties = map[string]any{}
+	}
+	tool.InputSchema.Properties["output_format"] = map[string]any{
+		"type":        "string",
+		"description": OutputFormatDescription,
+		"enum":        outputFormats,
+	}
+	return tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if format, _ := OptionalParam[string](request, "output_format"); format != "" {
+			ctx = ContextWithOutputFormat(ctx, ParseOutputFormat(format))
+		}
+		return handler(ctx, request)
+	}
+}
+
+type resultCaptureKey struct{}
+
+// resultCapture holds the last value MarshalResult serialized under a context.
+type resultCapture struct {
+	value any
+}
+
+// ContextWithResultCapture returns a context under which MarshalResult also
+// records the value it serializes, and a function returning that value (nil
+// if none). Middleware uses it to inspect a tool's result whatever output
+// format the text content was rendered in.
+func ContextWithResultCapture(ctx context.Context) (context.Context, func() any) {
+	c := &resultCapture{}
+	return context.WithValue(ctx, resultCaptureKey{}, c), func() any { return c.value }
+}
+
+// MarshalResult serializes the given value in the output format carried by
+// ctx and returns it as a text result for an MCP tool response.
 //
 // Values come from go-flashduty, whose Timestamp/TimestampMilli types already
 // render absolute instants as RFC3339, so no post-processing is needed.
-func MarshalResult(v any) *mcp.CallToolResult {
-	return marshalResultWithFormat(v, outputFormat)
+func MarshalResult(ctx context.Context, v any) *mcp.CallToolResult {
+	if c, ok := ctx.Value(resultCaptureKey{}).(*resultCapture); ok {
+		c.value = v
+	}
+	return marshalResultWithFormat(v, OutputFormatFromContext(ctx))
 }
 
 func marshalResultWithFormat(v any, format OutputFormat) *mcp.CallToolResult {
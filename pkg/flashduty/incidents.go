@@ -45,6 +45,8 @@ func QueryIncidents(getClient GetFlashdutyClientFn, t translations.TranslationHe
 			mcp.WithString("nums", mcp.Description("Comma-separated short incident ids (num — the 6-char id shown in the UI, e.g. 311510). Matched within the since/until window; the backend caps the list span at ~30 days, so incidents older than that must be looked up by their full incident_id.")),
 			mcp.WithNumber("limit", mcp.Description(LimitDescription), mcp.DefaultNumber(20), mcp.Min(1), mcp.Max(100)),
 			mcp.WithNumber("page", mcp.Description(PageDescription), mcp.DefaultNumber(1), mcp.Min(1)),
+			withFieldsParam(),
+			withResolveNamesParam(),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			ctx, client, err := getClient(ctx)
 			if err != nil {
@@ -59,6 +61,13 @@ func QueryIncidents(getClient GetFlashdutyClientFn, t translations.TranslationHe
 			query, _ := OptionalParam[string](request, "query")
 			nums, _ := OptionalParam[string](request, "nums")
 			limit, page := optionalPaging(request, defaultQueryLimit)
+			proj, resolve := optionalProjection(request), optionalResolveNames(request)
+			items := func(incidents []flashduty.IncidentInfo) any {
+				if resolve {
+					return resolveNames(ctx, client, proj.apply(incidents))
+				}
+				return proj.apply(incidents)
+			}
 
 			startTime, err := timeutil.ParseAny(args["since"])
 			if err != nil {
@@ -101,8 +110,8 @@ func QueryIncidents(getClient GetFlashdutyClientFn, t translations.TranslationHe
 					return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve incidents: %v", err)), nil
 				}
 				total := int(out.Total)
-				return MarshalResult(addTruncationHint(map[string]any{
-					"incidents": out.Items,
+				return MarshalResult(ctx, addTruncationHint(map[string]any{
+					"incidents": items(out.Items),
 					"total":     total,
 				}, len(out.Items), total)), nil
 			}
@@ -153,14 +162,14 @@ func QueryIncidents(getClient GetFlashdutyClientFn, t translations.TranslationHe
 			}
 
 			total := int(out.Total)
-			return MarshalResult(addPageHint(map[string]any{
-				"incidents": out.Items,
+			return MarshalResult(ctx, addPageHint(map[string]any{
+				"incidents": items(out.Items),
 				"total":     total,
 			}, len(out.Items), total, page, limit)), nil
 		}
 }
 
-const queryIncidentTimelineDescription = `Query timeline events for incidents. Returns events like created, assigned, acknowledged, resolved, notifications. Each event includes created_at (RFC3339) and creator_id (the actor's numeric ID, 0 = system); resolve creator_id to a display name with query_members when you need the actor's name.`
+const queryIncidentTimelineDescription = `Query timeline events for incidents. Returns events like created, assigned, acknowledged, resolved, notifications. Each event includes created_at (RFC3339) and creator_id (the actor's numeric ID, 0 = system); pass resolve_names=true to get the actor's creator_name alongside. An incident whose timeline cannot be loaded appears as an {incident_id, error} entry while the others are still returned.`
 
 // QueryIncidentTimeline creates a tool to query incident timeline
 func QueryIncidentTimeline(getClient GetFlashdutyClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
@@ -171,6 +180,8 @@ func QueryIncidentTimeline(getClient GetFlashdutyClientFn, t translations.Transl
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("incident_ids", mcp.Required(), mcp.Description("Comma-separated incident IDs to query timeline for. Event types: i_new (created), i_assign (assigned), i_ack (acknowledged), i_rslv (resolved), i_notify (notification), i_comm (comment), i_r_* (field updates).")),
+			withFieldsParam(),
+			withResolveNamesParam(),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			ctx, client, err := getClient(ctx)
 			if err != nil {
@@ -190,28 +201,44 @@ func QueryIncidentTimeline(getClient GetFlashdutyClientFn, t translations.Transl
 			// go-flashduty's Incidents.Feed returns one incident's timeline per
 			// call, so fan out across the requested IDs. Match the legacy
 			// asc/limit defaults the old SDK used for timeline fetches.
-			response := make([]map[string]any, 0, len(incidentIDs))
-			for _, id := range incidentIDs {
+			results := fanOut(ctx, request, incidentIDs, func(ctx context.Context, id string) (*flashduty.ListIncidentFeedResponse, error) {
 				feedReq := &flashduty.ListIncidentFeedRequest{IncidentID: id, Asc: true}
 				feedReq.Limit = 100
 				out, _, err := client.New.Incidents.Feed(ctx, feedReq)
-				if err != nil {
-					return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve timeline for %s: %v", id, err)), nil
+				return out, err
+			})
+			if allFailed(results) {
+				return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve timeline for %s: %v", results[0].ID, results[0].Err)), nil
+			}
+
+			proj := optionalProjection(request)
+			response := make([]map[string]any, 0, len(results))
+			for _, r := range results {
+				if r.Err != nil {
+					response = append(response, fanOutErrorEntry("incident_id", r.ID, r.Err))
+					continue
 				}
 				response = append(response, map[string]any{
-					"incident_id": id,
-					"timeline":    out.Items,
-					"total":       len(out.Items),
+					"incident_id": r.ID,
+					"timeline":    proj.apply(r.Value.Items),
+					"total":       len(r.Value.Items),
 				})
 			}
 
-			return MarshalResult(map[string]any{
-				"results": response,
+			// Resolve across every incident's timeline at once so shared actors
+			// cost a single lookup.
+			var out any = response
+			if optionalResolveNames(request) {
+				out = resolveNames(ctx, client, response)
+			}
+
+			return MarshalResult(ctx, map[string]any{
+				"results": out,
 			}), nil
 		}
 }
 
-const queryIncidentAlertsDescription = `Query alerts for incidents. Returns alerts with title, severity, status, and labels.`
+const queryIncidentAlertsDescription = `Query alerts for incidents. Returns alerts with title, severity, status, and labels. An incident whose alerts cannot be loaded appears as an {incident_id, error} entry while the others are still returned.`
 
 // QueryIncidentAlerts creates a tool to query incident alerts
 func QueryIncidentAlerts(getClient GetFlashdutyClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
@@ -224,6 +251,7 @@ func QueryIncidentAlerts(getClient GetFlashdutyClientFn, t translations.Translat
 			mcp.WithString("incident_ids", mcp.Required(), mcp.Description("Comma-separated incident IDs to query alerts for.")),
 			mcp.WithNumber("limit", mcp.Description("Maximum alerts per incident per page. Default 20, max 100. When an incident has more alerts than returned, its entry carries `truncated:true` and a `hint`."), mcp.DefaultNumber(20), mcp.Min(1), mcp.Max(100)),
 			mcp.WithNumber("page", mcp.Description("1-based page number, applied to every requested incident. Default 1. When an incident's entry is `truncated`, request `page:2` (then 3, …) to fetch its remaining alerts."), mcp.DefaultNumber(1), mcp.Min(1)),
+			withFieldsParam(),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			ctx, client, err := getClient(ctx)
 			if err != nil {
@@ -245,26 +273,35 @@ func QueryIncidentAlerts(getClient GetFlashdutyClientFn, t translations.Translat
 			// go-flashduty's Incidents.AlertList returns one incident's alerts
 			// per call, so fan out across the requested IDs. The page applies
 			// uniformly to each incident's alert list.
-			response := make([]map[string]any, 0, len(incidentIDs))
-			for _, id := range incidentIDs {
+			results := fanOut(ctx, request, incidentIDs, func(ctx context.Context, id string) (*flashduty.ListIncidentAlertsResponse, error) {
 				alertReq := &flashduty.ListIncidentAlertsRequest{IncidentID: id}
 				alertReq.Limit = limit
 				if page > 1 {
 					alertReq.Page = page
 				}
 				out, _, err := client.New.Incidents.AlertList(ctx, alertReq)
-				if err != nil {
-					return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve alerts for %s: %v", id, err)), nil
+				return out, err
+			})
+			if allFailed(results) {
+				return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve alerts for %s: %v", results[0].ID, results[0].Err)), nil
+			}
+
+			proj := optionalProjection(request)
+			response := make([]map[string]any, 0, len(results))
+			for _, r := range results {
+				if r.Err != nil {
+					response = append(response, fanOutErrorEntry("incident_id", r.ID, r.Err))
+					continue
 				}
-				total := int(out.Total)
+				total := int(r.Value.Total)
 				response = append(response, addPageHint(map[string]any{
-					"incident_id": id,
-					"alerts":      out.Items,
+					"incident_id": r.ID,
+					"alerts":      proj.apply(r.Value.Items),
 					"total":       total,
-				}, len(out.Items), total, page, limit))
+				}, len(r.Value.Items), total, page, limit))
 			}
 
-			return MarshalResult(map[string]any{
+			return MarshalResult(ctx, map[string]any{
 				"results": response,
 			}), nil
 		}
@@ -325,7 +362,7 @@ func CreateIncident(getClient GetFlashdutyClientFn, t translations.TranslationHe
 				return mcp.NewToolResultError(fmt.Sprintf("Unable to create incident: %v", err)), nil
 			}
 
-			return MarshalResult(out), nil
+			return MarshalResult(ctx, out), nil
 		}
 }
 
@@ -449,7 +486,7 @@ func UpdateIncident(getClient GetFlashdutyClientFn, t translations.TranslationHe
 				return mcp.NewToolResultError("no fields specified to update"), nil
 			}
 
-			return MarshalResult(map[string]any{
+			return MarshalResult(ctx, map[string]any{
 				"status":         "success",
 				"message":        "Incident updated successfully",
 				"updated_fields": updatedFields,
@@ -488,7 +525,7 @@ func AckIncident(getClient GetFlashdutyClientFn, t translations.TranslationHelpe
 				return mcp.NewToolResultError(fmt.Sprintf("Unable to acknowledge incidents: %v", err)), nil
 			}
 
-			return MarshalResult(map[string]string{
+			return MarshalResult(ctx, map[string]string{
 				"status":  "success",
 				"message": fmt.Sprintf("%d incident(s) acknowledged", len(incidentIDs)),
 			}), nil
@@ -526,13 +563,520 @@ func CloseIncident(getClient GetFlashdutyClientFn, t translations.TranslationHel
 				return mcp.NewToolResultError(fmt.Sprintf("Unable to close incidents: %v", err)), nil
 			}
 
-			return MarshalResult(map[string]string{
+			return MarshalResult(ctx, map[string]string{
 				"status":  "success",
 				"message": fmt.Sprintf("%d incident(s) closed", len(incidentIDs)),
 			}), nil
 		}
 }
 
+const commentIncidentDescription = `Add a comment to incidents' timelines (an i_comm entry), e.g. to post investigation findings or link dashboards. The body is markdown. Returns the created timeline entry for each incident.`
+
+// maxIncidentBatch is the backend's cap on incidents per batch write call
+// (comment, snooze, reopen).
+const maxIncidentBatch = 100
+
+// commentLookback is how many of an incident's newest comments are searched
+// for the one just posted.
+const commentLookback = 20
+
+// CommentIncident creates a tool to comment on incidents
+func CommentIncident(getClient GetFlashdutyClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("comment_incident",
+			mcp.WithDescription(t("TOOL_COMMENT_INCIDENT_DESCRIPTION", commentIncidentDescription)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMMENT_INCIDENT_USER_TITLE", "Comment on incident"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("incident_ids", mcp.Required(), mcp.Description("Comma-separated incident IDs to comment on. Max 100.")),
+			mcp.WithString("comment", mcp.Required(), mcp.Description("Comment body in markdown. Links to dashboards, logs or runbooks are rendered in the Flashduty console."), mcp.MinLength(1)),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Flashduty client: %w", err)
+			}
+
+			incidentIdsStr, err := RequiredParam[string](request, "incident_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			comment, err := RequiredParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if strings.TrimSpace(comment) == "" {
+				return mcp.NewToolResultError("comment must not be blank"), nil
+			}
+
+			incidentIDs := parseCommaSeparatedStrings(incidentIdsStr)
+			if len(incidentIDs) == 0 {
+				return mcp.NewToolResultError("incident_ids must contain at least one valid ID"), nil
+			}
+			if len(incidentIDs) > maxIncidentBatch {
+				return mcp.NewToolResultError(fmt.Sprintf("incident_ids accepts at most %d IDs per call", maxIncidentBatch)), nil
+			}
+
+			if _, err := client.New.Incidents.Comment(ctx, &flashduty.CommentIncidentRequest{
+				IncidentIDs: incidentIDs,
+				Comment:     comment,
+			}); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Unable to comment on incidents: %v", err)), nil
+			}
+
+			// /incident/comment returns no payload, so read each incident's
+			// newest comments back and pick the one just posted. The comment is
+			// already written at this point: a failed read-back is reported per
+			// incident instead of failing the call.
+			results := fanOut(ctx, request, incidentIDs, func(ctx context.Context, id string) (*flashduty.IncidentFeedItem, error) {
+				feedReq := &flashduty.ListIncidentFeedRequest{
+					IncidentID: id,
+					Types:      []flashduty.IncidentFeedType{flashduty.IncidentFeedTypeIComm},
+				}
+				feedReq.Limit = commentLookback
+				out, _, err := client.New.Incidents.Feed(ctx, feedReq)
+				if err != nil {
+					return nil, err
+				}
+				for i := range out.Items {
+					if detail, ok := out.Items[i].Detail.(map[string]any); ok && detail["comment"] == comment {
+						return &out.Items[i], nil
+					}
+				}
+				return nil, nil
+			})
+
+			response := make([]map[string]any, 0, len(results))
+			for _, r := range results {
+				switch {
+				case r.Err != nil:
+					response = append(response, fanOutErrorEntry("incident_id", r.ID, fmt.Errorf("comment added but the timeline could not be read back: %w", r.Err)))
+				case r.Value == nil:
+					response = append(response, map[string]any{
+						"incident_id": r.ID,
+						"hint":        "Comment added but not yet visible in the timeline; check query_incident_timeline shortly.",
+					})
+				default:
+					response = append(response, map[string]any{
+						"incident_id":    r.ID,
+						"timeline_entry": r.Value,
+					})
+				}
+			}
+
+			return MarshalResult(ctx, map[string]any{
+				"status":  "success",
+				"message": fmt.Sprintf("Comment added to %d incident(s)", len(incidentIDs)),
+				"results": response,
+			}), nil
+		}
+}
+
+const assignIncidentDescription = `Reassign incidents to people, teams or an escalation rule, or escalate them to the next level of their current escalation rule. Pass person_ids and/or team_ids (team members are expanded to people), OR escalate_rule_id (find IDs with query_escalation_rules), OR escalate=true. The reason is posted as a comment in each incident's timeline. Returns each incident's resulting assigned_to and responders.`
+
+// AssignIncident creates a tool to reassign or escalate incidents
+func AssignIncident(getClient GetFlashdutyClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("assign_incident",
+			mcp.WithDescription(t("TOOL_ASSIGN_INCIDENT_DESCRIPTION", assignIncidentDescription)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ASSIGN_INCIDENT_USER_TITLE", "Assign incident"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("incident_ids", mcp.Required(), mcp.Description("Comma-separated incident IDs to reassign.")),
+			mcp.WithString("reason", mcp.Required(), mcp.Description("Why the incident is being handed off. Posted to the timeline as a comment."), mcp.MinLength(1)),
+			mcp.WithString("person_ids", mcp.Description("Comma-separated person IDs to assign as responders. Use query_members to find IDs.")),
+			mcp.WithString("team_ids", mcp.Description("Comma-separated team IDs; their current members are assigned as responders. Use query_teams to find IDs.")),
+			mcp.WithString("escalate_rule_id", mcp.Description("Escalation rule ID to hand the incident to. Use query_escalation_rules to find IDs.")),
+			mcp.WithNumber("layer_idx", mcp.Description("0-based escalation level to start at when escalate_rule_id is set. Default 0."), mcp.Min(0)),
+			mcp.WithBoolean("escalate", mcp.Description("Set to true to escalate each incident to the next level of the escalation rule it is currently assigned through.")),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Flashduty client: %w", err)
+			}
+
+			incidentIdsStr, err := RequiredParam[string](request, "incident_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reason, err := RequiredParam[string](request, "reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if strings.TrimSpace(reason) == "" {
+				return mcp.NewToolResultError("reason must not be blank"), nil
+			}
+			personIdsStr, _ := OptionalParam[string](request, "person_ids")
+			teamIdsStr, _ := OptionalParam[string](request, "team_ids")
+			ruleID, _ := OptionalParam[string](request, "escalate_rule_id")
+			layerIdx, _ := OptionalInt(request, "layer_idx")
+			escalate, _ := OptionalParam[bool](request, "escalate")
+
+			incidentIDs := parseCommaSeparatedStrings(incidentIdsStr)
+			if len(incidentIDs) == 0 {
+				return mcp.NewToolResultError("incident_ids must contain at least one valid ID"), nil
+			}
+			if len(incidentIDs) > maxIncidentBatch {
+				return mcp.NewToolResultError(fmt.Sprintf("incident_ids accepts at most %d IDs per call", maxIncidentBatch)), nil
+			}
+
+			targets := 0
+			for _, set := range []bool{personIdsStr != "" || teamIdsStr != "", ruleID != "", escalate} {
+				if set {
+					targets++
+				}
+			}
+			if targets != 1 {
+				return mcp.NewToolResultError("specify exactly one target: person_ids and/or team_ids, escalate_rule_id, or escalate=true"), nil
+			}
+
+			var assigned []string
+			errs := map[string]error{}
+			switch {
+			case escalate:
+				// Each incident sits at its own level of its own rule, so the
+				// next level is worked out and assigned per incident.
+				results := fanOut(ctx, request, incidentIDs, func(ctx context.Context, id string) (struct{}, error) {
+					return struct{}{}, escalateIncident(ctx, client, id)
+				})
+				for _, r := range results {
+					if r.Err != nil {
+						errs[r.ID] = r.Err
+						continue
+					}
+					assigned = append(assigned, r.ID)
+				}
+				if len(assigned) == 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("Unable to escalate %s: %v", results[0].ID, results[0].Err)), nil
+				}
+			default:
+				assignedTo := flashduty.AssignedTo{Type: "reassign"}
+				if ruleID != "" {
+					assignedTo.EscalateRuleID = ruleID
+					assignedTo.LayerIdx = int64(layerIdx)
+				} else {
+					personIDs, err := assigneePersonIDs(ctx, client, personIdsStr, teamIdsStr)
+					if err != nil {
+						return mcp.NewToolResultError(err.Error()), nil
+					}
+					assignedTo.PersonIDs = personIDs
+				}
+				if _, err := client.New.Incidents.Assign(ctx, &flashduty.AssignIncidentRequest{
+					IncidentIDs: incidentIDs,
+					AssignedTo:  assignedTo,
+				}); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Unable to assign incidents: %v", err)), nil
+				}
+				assigned = incidentIDs
+			}
+
+			// The assign API has no reason field, so the reason goes to the
+			// timeline as a comment right after the assignment entry.
+			commentErr := ""
+			if _, err := client.New.Incidents.Comment(ctx, &flashduty.CommentIncidentRequest{
+				IncidentIDs: assigned,
+				Comment:     "Reassignment reason: " + reason,
+			}); err != nil {
+				commentErr = fmt.Sprintf("incidents were assigned but the reason could not be added to the timeline: %v", err)
+			}
+
+			state := map[string]flashduty.IncidentInfo{}
+			if out, _, err := client.New.Incidents.ListByIDs(ctx, &flashduty.ListIncidentsByIDsRequest{IncidentIDs: assigned}); err == nil {
+				for _, inc := range out.Items {
+					state[inc.IncidentID] = inc
+				}
+			}
+
+			response := make([]map[string]any, 0, len(incidentIDs))
+			for _, id := range incidentIDs {
+				if err, ok := errs[id]; ok {
+					response = append(response, fanOutErrorEntry("incident_id", id, err))
+					continue
+				}
+				entry := map[string]any{"incident_id": id}
+				if inc, ok := state[id]; ok {
+					entry["assigned_to"] = inc.AssignedTo
+					entry["responders"] = inc.Responders
+				}
+				response = append(response, entry)
+			}
+
+			res := map[string]any{
+				"status":  "success",
+				"message": fmt.Sprintf("%d incident(s) assigned", len(assigned)),
+				"results": response,
+			}
+			if commentErr != "" {
+				res["warning"] = commentErr
+			}
+			return MarshalResult(ctx, res), nil
+		}
+}
+
+// assigneePersonIDs merges person_ids with the current members of team_ids.
+// Team membership is read fresh: a handoff must reach whoever is on the team
+// now, not whoever was cached.
+func assigneePersonIDs(ctx context.Context, client *Clients, personIdsStr, teamIdsStr string) ([]int64, error) {
+	seen := map[int64]bool{}
+	var personIDs []int64
+	add := func(id int64) {
+		if id > 0 && !seen[id] {
+			seen[id] = true
+			personIDs = append(personIDs, id)
+		}
+	}
+	for _, id := range parseCommaSeparatedInts(personIdsStr) {
+		add(int64(id))
+	}
+
+	if teamIdsStr != "" {
+		var teamIDs []uint64
+		for _, id := range parseCommaSeparatedInts(teamIdsStr) {
+			if id > 0 {
+				teamIDs = append(teamIDs, uint64(id))
+			}
+		}
+		if len(teamIDs) == 0 {
+			return nil, fmt.Errorf("team_ids must contain at least one valid ID when specified")
+		}
+		out, err := teamInfos(ctx, client, true, &flashduty.TeamInfosRequest{TeamIDs: teamIDs})
+		if err != nil {
+			return nil, fmt.Errorf("unable to look up team members: %w", err)
+		}
+		for _, team := range out.Items {
+			for _, id := range team.PersonIDs {
+				add(int64(id))
+			}
+		}
+	}
+
+	if len(personIDs) == 0 {
+		return nil, fmt.Errorf("person_ids and team_ids resolved to no one to assign")
+	}
+	return personIDs, nil
+}
+
+// escalateIncident assigns incidentID to the level after the one it is at
+// in its current escalation rule.
+func escalateIncident(ctx context.Context, client *Clients, incidentID string) error {
+	inc, _, err := client.New.Incidents.Info(ctx, &flashduty.IncidentInfoRequest{IncidentID: incidentID})
+	if err != nil {
+		return err
+	}
+	current := inc.AssignedTo
+	if current.EscalateRuleID == "" {
+		return fmt.Errorf("incident is not assigned through an escalation rule; pass escalate_rule_id or person_ids instead")
+	}
+
+	rules, _, err := client.New.Channels.ChannelEscalateRuleList(ctx, &flashduty.ChannelScopedListRequest{ChannelID: inc.ChannelID})
+	if err != nil {
+		return fmt.Errorf("unable to load escalation rules: %w", err)
+	}
+	next := current.LayerIdx + 1
+	for _, rule := range rules.Items {
+		if rule.RuleID != current.EscalateRuleID {
+			continue
+		}
+		if next >= int64(len(rule.Layers)) {
+			return fmt.Errorf("incident is already at the last level (%d) of escalation rule %q", current.LayerIdx, rule.RuleName)
+		}
+		_, err := client.New.Incidents.Assign(ctx, &flashduty.AssignIncidentRequest{
+			IncidentID: incidentID,
+			AssignedTo: flashduty.AssignedTo{
+				Type:           "escalate",
+				EscalateRuleID: current.EscalateRuleID,
+				LayerIdx:       next,
+			},
+		})
+		return err
+	}
+	return fmt.Errorf("escalation rule %s was not found in channel %d", current.EscalateRuleID, inc.ChannelID)
+}
+
+// incidentState is the slice of an incident the lifecycle tools return after
+// changing it: enough to confirm the change without the full payload.
+var incidentState = newProjection("incident_id,num,title,incident_severity,progress,snoozed_before,close_time,assigned_to,responders.person_id,responders.person_name")
+
+// incidentStates reads incidentIDs back after a lifecycle change. The change
+// has already been made, so a failed read is returned as a warning rather
+// than an error.
+func incidentStates(ctx context.Context, client *Clients, incidentIDs []string) map[string]any {
+	out, _, err := client.New.Incidents.ListByIDs(ctx, &flashduty.ListIncidentsByIDsRequest{IncidentIDs: incidentIDs})
+	if err != nil {
+		return map[string]any{"warning": fmt.Sprintf("the change was applied but the resulting state could not be read: %v", err)}
+	}
+	return map[string]any{"incidents": incidentState.apply(out.Items)}
+}
+
+// maxSnooze is the longest snooze the backend accepts.
+const maxSnooze = 24 * time.Hour
+
+const snoozeIncidentDescription = `Snooze incidents: silence their notifications for a duration (e.g. "30m", "2h"; max 24h) while they stay open. Returns the resulting incident state, including snoozed_before.`
+
+// SnoozeIncident creates a tool to snooze incidents
+func SnoozeIncident(getClient GetFlashdutyClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("snooze_incident",
+			mcp.WithDescription(t("TOOL_SNOOZE_INCIDENT_DESCRIPTION", snoozeIncidentDescription)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SNOOZE_INCIDENT_USER_TITLE", "Snooze incident"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("incident_ids", mcp.Required(), mcp.Description("Comma-separated incident IDs to snooze. Max 100.")),
+			mcp.WithString("duration", mcp.Required(), mcp.Description("How long to snooze, as a duration: \"30m\", \"2h\", \"1h30m\". Between 1m and 24h.")),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Flashduty client: %w", err)
+			}
+
+			incidentIdsStr, err := RequiredParam[string](request, "incident_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			durationStr, err := RequiredParam[string](request, "duration")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			incidentIDs := parseCommaSeparatedStrings(incidentIdsStr)
+			if len(incidentIDs) == 0 {
+				return mcp.NewToolResultError("incident_ids must contain at least one valid ID"), nil
+			}
+			if len(incidentIDs) > maxIncidentBatch {
+				return mcp.NewToolResultError(fmt.Sprintf("incident_ids accepts at most %d IDs per call", maxIncidentBatch)), nil
+			}
+
+			duration, err := timeutil.ParseDuration(durationStr)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid duration: %v", err)), nil
+			}
+			if duration < time.Minute || duration > maxSnooze {
+				return mcp.NewToolResultError(fmt.Sprintf("duration must be between 1m and 24h, got %s", duration)), nil
+			}
+			// The backend counts whole minutes; round up so the snooze is never
+			// shorter than asked for.
+			minutes := int64((duration + time.Minute - 1) / time.Minute)
+
+			if _, err := client.New.Incidents.Snooze(ctx, &flashduty.SnoozeIncidentRequest{
+				IncidentIDs: incidentIDs,
+				Minutes:     minutes,
+			}); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Unable to snooze incidents: %v", err)), nil
+			}
+
+			res := incidentStates(ctx, client, incidentIDs)
+			res["status"] = "success"
+			res["message"] = fmt.Sprintf("%d incident(s) snoozed for %dm", len(incidentIDs), minutes)
+			return MarshalResult(ctx, res), nil
+		}
+}
+
+const reopenIncidentDescription = `Reopen closed incidents, e.g. when one was closed too early. Moves status back to Triggered and records the optional reason in the timeline. Returns the resulting incident state.`
+
+// ReopenIncident creates a tool to reopen closed incidents
+func ReopenIncident(getClient GetFlashdutyClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("reopen_incident",
+			mcp.WithDescription(t("TOOL_REOPEN_INCIDENT_DESCRIPTION", reopenIncidentDescription)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REOPEN_INCIDENT_USER_TITLE", "Reopen incident"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("incident_ids", mcp.Required(), mcp.Description("Comma-separated closed incident IDs to reopen. Max 100.")),
+			mcp.WithString("reason", mcp.Description("Why the incidents are being reopened. Recorded in the timeline.")),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Flashduty client: %w", err)
+			}
+
+			incidentIdsStr, err := RequiredParam[string](request, "incident_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reason, _ := OptionalParam[string](request, "reason")
+
+			incidentIDs := parseCommaSeparatedStrings(incidentIdsStr)
+			if len(incidentIDs) == 0 {
+				return mcp.NewToolResultError("incident_ids must contain at least one valid ID"), nil
+			}
+			if len(incidentIDs) > maxIncidentBatch {
+				return mcp.NewToolResultError(fmt.Sprintf("incident_ids accepts at most %d IDs per call", maxIncidentBatch)), nil
+			}
+
+			if _, err := client.New.Incidents.Reopen(ctx, &flashduty.ReopenIncidentRequest{
+				IncidentIDs: incidentIDs,
+				Reason:      strings.TrimSpace(reason),
+			}); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Unable to reopen incidents: %v", err)), nil
+			}
+
+			res := incidentStates(ctx, client, incidentIDs)
+			res["status"] = "success"
+			res["message"] = fmt.Sprintf("%d incident(s) reopened", len(incidentIDs))
+			return MarshalResult(ctx, res), nil
+		}
+}
+
+const mergeIncidentsDescription = `Merge duplicate incidents into a primary one. The source incidents' alerts move to the target incident and the sources are closed. Returns the resulting state of the target and source incidents.`
+
+// MergeIncidents creates a tool to merge duplicate incidents into one
+func MergeIncidents(getClient GetFlashdutyClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("merge_incidents",
+			mcp.WithDescription(t("TOOL_MERGE_INCIDENTS_DESCRIPTION", mergeIncidentsDescription)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MERGE_INCIDENTS_USER_TITLE", "Merge incidents"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("target_incident_id", mcp.Required(), mcp.Description("The primary incident the duplicates are merged into.")),
+			mcp.WithString("source_incident_ids", mcp.Required(), mcp.Description("Comma-separated IDs of the duplicate incidents to merge into the target.")),
+			mcp.WithString("comment", mcp.Description("Optional note recorded on the merge timeline entry, e.g. why these are duplicates.")),
+			mcp.WithString("title", mcp.Description("Optional new title for the target incident. Length: 3-200 characters."), mcp.MinLength(3), mcp.MaxLength(200)),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Flashduty client: %w", err)
+			}
+
+			targetID, err := RequiredParam[string](request, "target_incident_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sourceIdsStr, err := RequiredParam[string](request, "source_incident_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			comment, _ := OptionalParam[string](request, "comment")
+			title, _ := OptionalParam[string](request, "title")
+
+			targetID = strings.TrimSpace(targetID)
+			if targetID == "" {
+				return mcp.NewToolResultError("target_incident_id must not be blank"), nil
+			}
+			var sourceIDs []string
+			for _, id := range parseCommaSeparatedStrings(sourceIdsStr) {
+				if id != targetID {
+					sourceIDs = append(sourceIDs, id)
+				}
+			}
+			if len(sourceIDs) == 0 {
+				return mcp.NewToolResultError("source_incident_ids must contain at least one ID other than target_incident_id"), nil
+			}
+
+			if _, err := client.New.Incidents.Merge(ctx, &flashduty.MergeIncidentsRequest{
+				TargetIncidentID:  targetID,
+				SourceIncidentIDs: sourceIDs,
+				Comment:           comment,
+				Title:             title,
+			}); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Unable to merge incidents: %v", err)), nil
+			}
+
+			res := incidentStates(ctx, client, append([]string{targetID}, sourceIDs...))
+			res["status"] = "success"
+			res["message"] = fmt.Sprintf("%d incident(s) merged into %s", len(sourceIDs), targetID)
+			return MarshalResult(ctx, res), nil
+		}
+}
+
 const listSimilarIncidentsDescription = `Find similar historical incidents. Useful for reviewing past resolutions and identifying recurring issues.`
 
 // ListSimilarIncidents creates a tool to find similar incidents
@@ -545,6 +1089,7 @@ func ListSimilarIncidents(getClient GetFlashdutyClientFn, t translations.Transla
 			}),
 			mcp.WithString("incident_id", mcp.Required(), mcp.Description("Reference incident ID to find similar historical incidents for.")),
 			mcp.WithNumber("limit", mcp.Description("Maximum number of similar incidents to return."), mcp.DefaultNumber(20), mcp.Min(1), mcp.Max(100)),
+			withFieldsParam(),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			ctx, client, err := getClient(ctx)
 			if err != nil {
@@ -572,8 +1117,8 @@ func ListSimilarIncidents(getClient GetFlashdutyClientFn, t translations.Transla
 			// PastList returns the full similar set without a separate total, so
 			// the count is the slice length.
 			total := len(out.Items)
-			return MarshalResult(addTruncationHint(map[string]any{
-				"incidents": out.Items,
+			return MarshalResult(ctx, addTruncationHint(map[string]any{
+				"incidents": optionalProjection(request).apply(out.Items),
 				"total":     total,
 			}, total, total)), nil
 		}
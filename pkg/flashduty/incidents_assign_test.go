@@ -0,0 +1,106 @@
+package flashduty
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
+)
+
+// assignAPI fakes the endpoints assign_incident uses.
+func assignAPI(t *testing.T, incident map[string]any, rules []any) *fakeAPI {
+	t.Helper()
+	return newFakeAPI(t, map[string]fakeRoute{
+		"/team/infos":                 reply(map[string]any{"items": []any{map[string]any{"team_id": 5, "person_ids": []any{2, 3}}}}),
+		"/incident/info":              reply(incident),
+		"/channel/escalate/rule/list": reply(map[string]any{"items": rules}),
+		"/incident/list-by-ids":       reply(map[string]any{"items": []any{map[string]any{"incident_id": "a1", "assigned_to": map[string]any{"person_ids": []any{1, 2, 3}}}}}),
+		"/incident/assign":            reply(nil),
+		"/incident/comment":           reply(nil),
+	})
+}
+
+func TestAssignIncidentExpandsTeamsAndRecordsReason(t *testing.T) {
+	t.Parallel()
+
+	api := assignAPI(t, nil, nil)
+	_, handler := AssignIncident(newTestClients(t, api.URL), translations.NullTranslationHelper)
+	res := callResult(t, handler, "assign_incident", map[string]any{
+		"incident_ids": "a1",
+		"person_ids":   "1,2",
+		"team_ids":     "5",
+		"reason":       "handing off to the database team",
+	})
+	out := resultText(t, res)
+
+	assign := api.requests("/incident/assign")
+	require.Len(t, assign, 1)
+	assert.Equal(t, []any{1.0, 2.0, 3.0}, assign[0]["assigned_to"].(map[string]any)["person_ids"])
+
+	comment := api.requests("/incident/comment")
+	require.Len(t, comment, 1)
+	assert.Equal(t, "Reassignment reason: handing off to the database team", comment[0]["comment"])
+	assert.Contains(t, out, `"assigned_to"`)
+}
+
+func TestAssignIncidentEscalatesToNextLevel(t *testing.T) {
+	t.Parallel()
+
+	incident := map[string]any{
+		"incident_id": "a1",
+		"channel_id":  9,
+		"assigned_to": map[string]any{"escalate_rule_id": "r1", "layer_idx": 0},
+	}
+	rules := []any{map[string]any{"rule_id": "r1", "rule_name": "db", "layers": []any{map[string]any{}, map[string]any{}}}}
+
+	api := assignAPI(t, incident, rules)
+	_, handler := AssignIncident(newTestClients(t, api.URL), translations.NullTranslationHelper)
+	resultText(t, callResult(t, handler, "assign_incident", map[string]any{"incident_ids": "a1", "escalate": true, "reason": "no ack"}))
+	assign := api.requests("/incident/assign")
+	require.Len(t, assign, 1)
+	assert.Equal(t, 1.0, assign[0]["assigned_to"].(map[string]any)["layer_idx"])
+	assert.Equal(t, "r1", assign[0]["assigned_to"].(map[string]any)["escalate_rule_id"])
+
+	// Already at the last level: nothing is assigned or commented.
+	incident["assigned_to"] = map[string]any{"escalate_rule_id": "r1", "layer_idx": 1}
+	api = assignAPI(t, incident, rules)
+	_, handler = AssignIncident(newTestClients(t, api.URL), translations.NullTranslationHelper)
+	res := callResult(t, handler, "assign_incident", map[string]any{"incident_ids": "a1", "escalate": true, "reason": "no ack"})
+	assert.True(t, res.IsError)
+	assert.Empty(t, api.requests("/incident/assign"))
+	assert.Empty(t, api.requests("/incident/comment"))
+}
+
+func TestAssignIncidentRequiresExactlyOneTarget(t *testing.T) {
+	t.Parallel()
+
+	api := assignAPI(t, nil, nil)
+	_, handler := AssignIncident(newTestClients(t, api.URL), translations.NullTranslationHelper)
+	for _, args := range []map[string]any{
+		{"incident_ids": "a1", "reason": "x"},
+		{"incident_ids": "a1", "reason": "x", "person_ids": "1", "escalate": true},
+		{"incident_ids": "a1", "reason": "x", "escalate_rule_id": "r1", "team_ids": "5"},
+	} {
+		assert.True(t, callResult(t, handler, "assign_incident", args).IsError, "args %v", args)
+	}
+}
+
+func TestAssignIncidentRejectsOversizedBatch(t *testing.T) {
+	t.Parallel()
+
+	api := assignAPI(t, nil, nil)
+	_, handler := AssignIncident(newTestClients(t, api.URL), translations.NullTranslationHelper)
+	ids := make([]string, maxIncidentBatch+1)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("i%d", i)
+	}
+	res := callResult(t, handler, "assign_incident", map[string]any{"incident_ids": strings.Join(ids, ","), "reason": "x", "person_ids": "1"})
+	require.True(t, res.IsError)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "at most 100")
+	assert.Empty(t, api.requests("/incident/assign"))
+}
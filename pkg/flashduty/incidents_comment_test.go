@@ -0,0 +1,57 @@
+package flashduty
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
+)
+
+func TestCommentIncidentReturnsCreatedEntries(t *testing.T) {
+	t.Parallel()
+
+	const body = "Grafana: https://grafana.example.com/d/db"
+	api := newFakeAPI(t, map[string]fakeRoute{
+		"/incident/comment": func(req map[string]any) any {
+			assert.Equal(t, body, req["comment"])
+			assert.Equal(t, []any{"a1", "a2"}, req["incident_ids"])
+			return nil
+		},
+		"/incident/feed": func(req map[string]any) any {
+			assert.Equal(t, []any{"i_comm"}, req["types"])
+			items := []any{map[string]any{"type": "i_comm", "creator_id": 7, "detail": map[string]any{"comment": "older"}}}
+			if req["incident_id"] == "a1" {
+				items = append([]any{map[string]any{"type": "i_comm", "creator_id": 7, "detail": map[string]any{"comment": body}}}, items...)
+			}
+			return map[string]any{"items": items}
+		},
+	})
+	tool, handler := CommentIncident(newTestClients(t, api.URL), translations.NullTranslationHelper)
+	require.False(t, *tool.Annotations.ReadOnlyHint)
+
+	res := callResult(t, handler, "comment_incident", map[string]any{"incident_ids": "a1,a2", "comment": body})
+
+	var payload struct {
+		Results []map[string]any `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resultText(t, res)), &payload))
+	require.Len(t, payload.Results, 2)
+	entry := payload.Results[0]["timeline_entry"].(map[string]any)
+	assert.Equal(t, body, entry["detail"].(map[string]any)["comment"])
+	assert.NotContains(t, payload.Results[1], "timeline_entry")
+	assert.Contains(t, payload.Results[1], "hint")
+}
+
+func TestCommentIncidentRejectsBlankComment(t *testing.T) {
+	t.Parallel()
+
+	_, handler := CommentIncident(func(ctx context.Context) (context.Context, *Clients, error) {
+		return ctx, &Clients{}, nil
+	}, translations.NullTranslationHelper)
+	res := callResult(t, handler, "comment_incident", map[string]any{"incident_ids": "a1", "comment": "  "})
+	assert.True(t, res.IsError)
+}
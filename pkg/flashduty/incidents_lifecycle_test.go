@@ -0,0 +1,88 @@
+package flashduty
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
+)
+
+// lifecycleAPI fakes the write endpoints under test plus the list-by-ids
+// read-back.
+func lifecycleAPI(t *testing.T) *fakeAPI {
+	t.Helper()
+	return newFakeAPI(t, map[string]fakeRoute{
+		"/incident/snooze": reply(nil),
+		"/incident/reopen": reply(nil),
+		"/incident/merge":  reply(nil),
+		"/incident/list-by-ids": func(req map[string]any) any {
+			items := []any{}
+			for _, id := range req["incident_ids"].([]any) {
+				items = append(items, map[string]any{"incident_id": id, "progress": "Processing", "description": "dropped"})
+			}
+			return map[string]any{"items": items}
+		},
+	})
+}
+
+func TestSnoozeIncidentParsesDuration(t *testing.T) {
+	t.Parallel()
+
+	api := lifecycleAPI(t)
+	_, handler := SnoozeIncident(newTestClients(t, api.URL), translations.NullTranslationHelper)
+
+	out := resultText(t, callResult(t, handler, "snooze_incident", map[string]any{"incident_ids": "a1,a2", "duration": "1h30m30s"}))
+	require.Len(t, api.requests("/incident/snooze"), 1)
+	snooze := api.requests("/incident/snooze")[0]
+	assert.Equal(t, 91.0, snooze["minutes"], "partial minutes round up")
+	assert.Equal(t, []any{"a1", "a2"}, snooze["incident_ids"])
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &payload))
+	incidents := payload["incidents"].([]any)
+	require.Len(t, incidents, 2)
+	first := incidents[0].(map[string]any)
+	assert.Equal(t, "Processing", first["progress"])
+	assert.Contains(t, first, "snoozed_before")
+	assert.NotContains(t, first, "description", "state is trimmed to lifecycle fields")
+
+	for _, duration := range []string{"25h", "10s", "soon"} {
+		res := callResult(t, handler, "snooze_incident", map[string]any{"incident_ids": "a1", "duration": duration})
+		assert.True(t, res.IsError, "duration %q", duration)
+	}
+}
+
+func TestReopenIncidentSendsReason(t *testing.T) {
+	t.Parallel()
+
+	api := lifecycleAPI(t)
+	_, handler := ReopenIncident(newTestClients(t, api.URL), translations.NullTranslationHelper)
+
+	resultText(t, callResult(t, handler, "reopen_incident", map[string]any{"incident_ids": "a1", "reason": "alerts fired again"}))
+	require.Len(t, api.requests("/incident/reopen"), 1)
+	assert.Equal(t, "alerts fired again", api.requests("/incident/reopen")[0]["reason"])
+}
+
+func TestMergeIncidentsDropsTargetFromSources(t *testing.T) {
+	t.Parallel()
+
+	api := lifecycleAPI(t)
+	_, handler := MergeIncidents(newTestClients(t, api.URL), translations.NullTranslationHelper)
+
+	resultText(t, callResult(t, handler, "merge_incidents", map[string]any{"target_incident_id": "a1", "source_incident_ids": "a1,a2,a3"}))
+	require.Len(t, api.requests("/incident/merge"), 1)
+	merge := api.requests("/incident/merge")[0]
+	assert.Equal(t, "a1", merge["target_incident_id"])
+	assert.Equal(t, []any{"a2", "a3"}, merge["source_incident_ids"])
+	assert.Equal(t, []any{"a1", "a2", "a3"}, api.requests("/incident/list-by-ids")[0]["incident_ids"])
+
+	res := callResult(t, handler, "merge_incidents", map[string]any{"target_incident_id": "a1", "source_incident_ids": "a1"})
+	assert.True(t, res.IsError)
+
+	res = callResult(t, handler, "merge_incidents", map[string]any{"target_incident_id": " ", "source_incident_ids": "a2"})
+	assert.True(t, res.IsError)
+	assert.Len(t, api.requests("/incident/merge"), 1, "a blank target is not sent")
+}
@@ -1,6 +1,7 @@
 package flashduty
 
 import (
+	"context"
 	"strconv"
 	"strings"
 	"testing"
@@ -52,7 +53,7 @@ func TestMarshalResultRendersTimestampsAsRFC3339(t *testing.T) {
 		// year + 'T' separator are present in both.
 		text string
 	}{
-		{name: "default-json", format: GetOutputFormat()},
+		{name: "default-json", format: OutputFormatFromContext(context.Background())},
 		{name: "json", format: OutputFormatJSON},
 		{name: "toon", format: OutputFormatTOON},
 	}
@@ -77,17 +78,43 @@ func TestMarshalResultRendersTimestampsAsRFC3339(t *testing.T) {
 	}
 }
 
-// TestMarshalResultUsesGlobalFormat covers the no-arg MarshalResult path so the
-// default-format wrapper is exercised too.
-func TestMarshalResultUsesGlobalFormat(t *testing.T) {
+// TestMarshalResultUsesContextFormat covers the MarshalResult path so the
+// format carried by the context, and the JSON default, are exercised too.
+func TestMarshalResultUsesContextFormat(t *testing.T) {
 	secs := int64(1748487600)
 	wantYear := strconv.Itoa(time.Unix(secs, 0).Year())
 
 	fixture := timeFixture{CreatedAt: flashduty.Timestamp(secs)}
 
-	out := resultText(t, MarshalResult(fixture))
+	out := resultText(t, MarshalResult(context.Background(), fixture))
 	assert.True(t, strings.Contains(out, wantYear) && strings.Contains(out, "T"),
 		"expected RFC3339 timestamp in default-format output %q", out)
 	assert.NotContains(t, out, strconv.FormatInt(secs, 10),
 		"raw epoch-seconds leaked into default-format output %q", out)
+	assert.True(t, strings.HasPrefix(out, "{"), "expected JSON by default, got %q", out)
+
+	ctx := ContextWithOutputFormat(context.Background(), OutputFormatTOON)
+	out = resultText(t, MarshalResult(ctx, fixture))
+	assert.False(t, strings.HasPrefix(out, "{"), "expected TOON from the context, got %q", out)
+}
+
+// TestOutputFormatArgument verifies the `output_format` argument every tool
+// accepts overrides the session's format for that call only.
+func TestOutputFormatArgument(t *testing.T) {
+	t.Parallel()
+
+	tool, handler := withOutputFormat(mcp.NewTool("echo"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return MarshalResult(ctx, map[string]any{"total": 1}), nil
+	})
+	require.Contains(t, tool.InputSchema.Properties, "output_format")
+
+	call := func(ctx context.Context, args map[string]any) string {
+		res, err := handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "echo", Arguments: args}})
+		require.NoError(t, err)
+		return resultText(t, res)
+	}
+	session := ContextWithOutputFormat(context.Background(), OutputFormatTOON)
+	assert.Equal(t, "total: 1", call(session, nil))
+	assert.Equal(t, `{"total":1}`, call(session, map[string]any{"output_format": "json"}))
+	assert.Equal(t, "total: 1", call(context.Background(), map[string]any{"output_format": "toon"}))
 }
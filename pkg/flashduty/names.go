@@ -0,0 +1,228 @@
+package flashduty
+
+import (
+	"context"
+	"slices"
+
+	flashduty "github.com/flashcatcloud/go-flashduty"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ResolveNamesDescription is the canonical description for the
+// `resolve_names` parameter.
+const ResolveNamesDescription = "Set to true to add a *_name field next to every person, team and channel ID in the result (e.g. creator_name next to creator_id, person_names next to person_ids), resolved in one batch per kind. Saves separate query_members/query_teams/query_channels calls; IDs that cannot be resolved are left without a name."
+
+// nameLookupBatch caps the IDs sent in one reference lookup; /team/infos and
+// /channel/list both reject larger batches.
+const nameLookupBatch = 100
+
+// withResolveNamesParam declares the `resolve_names` parameter.
+func withResolveNamesParam() mcp.ToolOption {
+	return mcp.WithBoolean("resolve_names", mcp.Description(ResolveNamesDescription))
+}
+
+// optionalResolveNames reports whether the tool call asked for names.
+func optionalResolveNames(r mcp.CallToolRequest) bool {
+	v, _ := OptionalParam[bool](r, "resolve_names")
+	return v
+}
+
+type refKind int
+
+const (
+	refPerson refKind = iota
+	refTeam
+	refChannel
+)
+
+// nameRef describes an ID-carrying key: the kind of entity it references and
+// the key its resolved name is written to.
+type nameRef struct {
+	kind    refKind
+	nameKey string
+	list    bool
+}
+
+// nameRefs lists the ID keys resolve_names understands, as they appear in
+// incidents, timeline entries and escalation rules.
+var nameRefs = map[string]nameRef{
+	"person_id":   {kind: refPerson, nameKey: "person_name"},
+	"creator_id":  {kind: refPerson, nameKey: "creator_name"},
+	"closer_id":   {kind: refPerson, nameKey: "closer_name"},
+	"owner_id":    {kind: refPerson, nameKey: "owner_name"},
+	"updated_by":  {kind: refPerson, nameKey: "updated_by_name"},
+	"person_ids":  {kind: refPerson, nameKey: "person_names", list: true},
+	"team_id":     {kind: refTeam, nameKey: "team_name"},
+	"team_ids":    {kind: refTeam, nameKey: "team_names", list: true},
+	"channel_id":  {kind: refChannel, nameKey: "channel_name"},
+	"channel_ids": {kind: refChannel, nameKey: "channel_names", list: true},
+}
+
+// resolveNames adds a name field next to each known ID key in v and returns
+// the enriched JSON form. Person, team and channel IDs are collected across
+// the whole value first so each kind costs one batched, cached lookup. Names
+// the API already filled in are kept. Lookups are best effort: a failed
+// lookup leaves its IDs unnamed rather than failing the tool call.
+func resolveNames(ctx context.Context, client *Clients, v any) any {
+	generic, err := toGeneric(v)
+	if err != nil {
+		return v
+	}
+
+	ids := map[refKind]map[int64]bool{refPerson: {}, refTeam: {}, refChannel: {}}
+	walkNameRefs(generic, func(_ map[string]any, ref nameRef, id []int64) {
+		for _, i := range id {
+			ids[ref.kind][i] = true
+		}
+	})
+	if len(ids[refPerson])+len(ids[refTeam])+len(ids[refChannel]) == 0 {
+		return generic
+	}
+
+	names := map[refKind]map[int64]string{
+		refPerson:  lookupPersonNames(ctx, client, sortedIDs(ids[refPerson])),
+		refTeam:    lookupTeamNames(ctx, client, sortedIDs(ids[refTeam])),
+		refChannel: lookupChannelNames(ctx, client, sortedIDs(ids[refChannel])),
+	}
+
+	walkNameRefs(generic, func(obj map[string]any, ref nameRef, id []int64) {
+		if existing, ok := obj[ref.nameKey]; ok && existing != "" && existing != nil {
+			return
+		}
+		if !ref.list {
+			if name, ok := names[ref.kind][id[0]]; ok {
+				obj[ref.nameKey] = name
+			}
+			return
+		}
+		resolved := make([]string, 0, len(id))
+		for _, i := range id {
+			if name, ok := names[ref.kind][i]; ok {
+				resolved = append(resolved, name)
+			}
+		}
+		if len(resolved) > 0 {
+			obj[ref.nameKey] = resolved
+		}
+	})
+	return generic
+}
+
+// walkNameRefs calls fn for every object key in v that nameRefs knows, with
+// the non-zero IDs it holds.
+func walkNameRefs(v any, fn func(obj map[string]any, ref nameRef, ids []int64)) {
+	switch val := v.(type) {
+	case map[string]any:
+		for key, field := range val {
+			if ref, ok := nameRefs[key]; ok {
+				if ids := refIDs(field, ref.list); len(ids) > 0 {
+					fn(val, ref, ids)
+				}
+				continue
+			}
+			walkNameRefs(field, fn)
+		}
+	case []any:
+		for _, item := range val {
+			walkNameRefs(item, fn)
+		}
+	}
+}
+
+func refIDs(v any, list bool) []int64 {
+	if !list {
+		if id := refID(v); id > 0 {
+			return []int64{id}
+		}
+		return nil
+	}
+	items, _ := v.([]any)
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		if id := refID(item); id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func refID(v any) int64 {
+	id, _ := v.(int64)
+	return id
+}
+
+// lookupPersonNames resolves member display names by ID.
+func lookupPersonNames(ctx context.Context, client *Clients, ids []int64) map[int64]string {
+	names := map[int64]string{}
+	for _, batch := range batchIDs(ids) {
+		req := &flashduty.PersonInfosRequest{PersonIDs: make([]uint64, len(batch))}
+		for i, id := range batch {
+			req.PersonIDs[i] = uint64(id)
+		}
+		out, err := personInfos(ctx, client, false, req)
+		if err != nil {
+			continue
+		}
+		for _, p := range out.Items {
+			names[int64(p.PersonID)] = p.PersonName
+		}
+	}
+	return names
+}
+
+// lookupTeamNames resolves team names by ID.
+func lookupTeamNames(ctx context.Context, client *Clients, ids []int64) map[int64]string {
+	names := map[int64]string{}
+	for _, batch := range batchIDs(ids) {
+		req := &flashduty.TeamInfosRequest{TeamIDs: make([]uint64, len(batch))}
+		for i, id := range batch {
+			req.TeamIDs[i] = uint64(id)
+		}
+		out, err := teamInfos(ctx, client, false, req)
+		if err != nil {
+			continue
+		}
+		for _, team := range out.Items {
+			names[int64(team.TeamID)] = team.TeamName
+		}
+	}
+	return names
+}
+
+// lookupChannelNames resolves channel names by ID through the brief
+// /channel/list projection.
+func lookupChannelNames(ctx context.Context, client *Clients, ids []int64) map[int64]string {
+	names := map[int64]string{}
+	for _, batch := range batchIDs(ids) {
+		req := &flashduty.ListChannelsRequest{ChannelIDs: batch, IsBrief: true}
+		req.Limit = len(batch)
+		out, err := channelList(ctx, client, false, req)
+		if err != nil {
+			continue
+		}
+		for _, ch := range out.Items {
+			names[ch.ChannelID] = ch.ChannelName
+		}
+	}
+	return names
+}
+
+func batchIDs(ids []int64) [][]int64 {
+	var batches [][]int64
+	for len(ids) > 0 {
+		n := min(len(ids), nameLookupBatch)
+		batches = append(batches, ids[:n])
+		ids = ids[n:]
+	}
+	return batches
+}
+
+func sortedIDs(set map[int64]bool) []int64 {
+	ids := make([]int64, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	// Sorted so the cache key of a lookup does not depend on map order.
+	slices.Sort(ids)
+	return ids
+}
@@ -0,0 +1,74 @@
+package flashduty
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
+)
+
+func TestQueryIncidentTimelineResolvesNames(t *testing.T) {
+	t.Parallel()
+
+	var personLookups atomic.Int32
+	api := newFakeAPI(t, map[string]fakeRoute{
+		"/incident/feed": reply(map[string]any{"items": []any{
+			map[string]any{"type": "i_new", "creator_id": 0},
+			map[string]any{"type": "i_comm", "creator_id": 7, "detail": map[string]any{"comment": "looking"}},
+			map[string]any{"type": "i_assign", "creator_id": 8, "detail": map[string]any{"person_ids": []any{7, 9}}},
+		}}),
+		"/person/infos": func(req map[string]any) any {
+			personLookups.Add(1)
+			assert.ElementsMatch(t, []any{7.0, 8.0, 9.0}, req["person_ids"])
+			return map[string]any{"items": []any{
+				map[string]any{"person_id": 7, "person_name": "Alice"},
+				map[string]any{"person_id": 8, "person_name": "Bob"},
+			}}
+		},
+	})
+	_, handler := QueryIncidentTimeline(newTestClients(t, api.URL), translations.NullTranslationHelper)
+
+	res := callResult(t, handler, "query_incident_timeline", map[string]any{"incident_ids": "a1,a2", "resolve_names": true})
+
+	var payload struct {
+		Results []struct {
+			Timeline []map[string]any `json:"timeline"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resultText(t, res)), &payload))
+	require.Len(t, payload.Results, 2)
+
+	// Both incidents' timelines share one lookup.
+	assert.Equal(t, int32(1), personLookups.Load())
+	timeline := payload.Results[0].Timeline
+	require.Len(t, timeline, 3)
+	assert.NotContains(t, timeline[0], "creator_name", "the system actor (0) has no name")
+	assert.Equal(t, "Alice", timeline[1]["creator_name"])
+	assert.Equal(t, "Bob", timeline[2]["creator_name"])
+	assert.Equal(t, []any{"Alice"}, timeline[2]["detail"].(map[string]any)["person_names"], "unknown IDs are left unnamed")
+}
+
+func TestResolveNamesKeepsExistingNames(t *testing.T) {
+	t.Parallel()
+
+	api := newFakeAPI(t, map[string]fakeRoute{
+		"/channel/list": reply(map[string]any{
+			"items": []any{map[string]any{"channel_id": 3, "channel_name": "payments"}},
+		}),
+	})
+	_, client, err := newTestClients(t, api.URL)(context.Background())
+	require.NoError(t, err)
+
+	got := resolveNames(context.Background(), client, []map[string]any{
+		{"incident_id": "a1", "channel_id": 3},
+		{"incident_id": "a2", "channel_id": 3, "channel_name": "from-api"},
+	})
+	items := got.([]any)
+	assert.Equal(t, "payments", items[0].(map[string]any)["channel_name"])
+	assert.Equal(t, "from-api", items[1].(map[string]any)["channel_name"])
+}
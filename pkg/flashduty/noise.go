@@ -0,0 +1,181 @@
+package flashduty
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	flashduty "github.com/flashcatcloud/go-flashduty"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
+)
+
+// maxNoiseScan caps how many alerts one analyze_alert_noise call reads.
+const maxNoiseScan = 5000
+
+const analyzeAlertNoiseDescription = `Find the noisiest alert sources in a time window. Groups alerts by title plus a label fingerprint and ranks the groups by volume, reporting for each: count, share of all alerts, trigger/recover cycles, flaps per day, median and p90 time to recover, how many are still active, and how many led to an incident. High counts with short durations and few incidents are candidates for tuning, silencing or inhibition; pass a group's labels to query_alerts to see its alerts.`
+
+// AnalyzeAlertNoise creates a tool that ranks alert groups by noise
+func AnalyzeAlertNoise(getClient GetFlashdutyClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("analyze_alert_noise",
+			mcp.WithDescription(t("TOOL_ANALYZE_ALERT_NOISE_DESCRIPTION", analyzeAlertNoiseDescription)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ANALYZE_ALERT_NOISE_USER_TITLE", "Analyze alert noise"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithSince(mcp.Description(alertSinceDescription)),
+			WithUntil(),
+			mcp.WithString("channel_ids", mcp.Description("Comma-separated collaboration space IDs to analyze. Default: all channels.")),
+			mcp.WithString("fingerprint_labels", mcp.Description("Comma-separated label keys that make up the fingerprint, e.g. \"alertname,service\". Default: all labels. Leave out per-instance keys like host or pod to fold a rule's alerts across instances into one group.")),
+			mcp.WithNumber("limit", mcp.Description("Maximum number of groups to return."), mcp.DefaultNumber(20), mcp.Min(1), mcp.Max(100)),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Flashduty client: %w", err)
+			}
+
+			channelIdsStr, _ := OptionalParam[string](request, "channel_ids")
+			fingerprintStr, _ := OptionalParam[string](request, "fingerprint_labels")
+			limit, _ := optionalPaging(request, defaultQueryLimit)
+
+			startTime, endTime, err := alertWindow(request.GetArguments())
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			req := &flashduty.AlertListRequest{StartTime: startTime, EndTime: endTime}
+			if channelIdsStr != "" {
+				channelIDs := parseCommaSeparatedInts(channelIdsStr)
+				if len(channelIDs) == 0 {
+					return mcp.NewToolResultError("channel_ids must contain at least one valid ID when specified"), nil
+				}
+				req.ChannelIDs = make([]int64, len(channelIDs))
+				for i, id := range channelIDs {
+					req.ChannelIDs[i] = int64(id)
+				}
+			}
+
+			alerts, complete, err := scanAlerts(ctx, client, req, nil, maxNoiseScan)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve alerts: %v", err)), nil
+			}
+
+			window := time.Duration(endTime-startTime) * time.Second
+			groups := alertNoiseGroups(alerts, parseCommaSeparatedStrings(fingerprintStr), window, time.Now())
+			result := map[string]any{
+				"total_alerts": len(alerts),
+				"total_groups": len(groups),
+				"groups":       groups[:min(limit, len(groups))],
+			}
+			if !complete {
+				result["truncated"] = true
+				result["hint"] = fmt.Sprintf("Only the latest %d alerts in the window were analyzed. Narrow since/until or channel_ids to cover the rest.", maxNoiseScan)
+			}
+			return MarshalResult(ctx, result), nil
+		}
+}
+
+// alertNoiseGroup is one row of an analyze_alert_noise result.
+type alertNoiseGroup struct {
+	Title        string            `json:"title"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	Channels     []string          `json:"channels,omitempty"`
+	Count        int               `json:"count"`
+	Share        float64           `json:"share"`
+	Cycles       int               `json:"cycles"`
+	FlapsPerDay  float64           `json:"flaps_per_day"`
+	MedianTTR    string            `json:"median_duration,omitempty"`
+	P90TTR       string            `json:"p90_duration,omitempty"`
+	Active       int               `json:"active"`
+	Incidents    int               `json:"incidents"`
+	SampleAlerts []string          `json:"sample_alert_ids"`
+
+	durations []time.Duration
+	incidents map[string]bool
+}
+
+// alertNoiseGroups groups alerts by title and fingerprint and ranks the
+// groups, noisiest first. Each recovered alert is one trigger/recover cycle;
+// flaps per day normalizes cycles by the window length.
+func alertNoiseGroups(alerts []flashduty.AlertItem, fingerprintKeys []string, window time.Duration, now time.Time) []*alertNoiseGroup {
+	byKey := map[string]*alertNoiseGroup{}
+	var order []*alertNoiseGroup
+	for _, a := range alerts {
+		labels := alertFingerprint(a.Labels, fingerprintKeys)
+		key := a.Title + "\x00" + formatLabels(labels)
+		g, ok := byKey[key]
+		if !ok {
+			g = &alertNoiseGroup{Title: a.Title, Labels: labels, incidents: map[string]bool{}}
+			byKey[key] = g
+			order = append(order, g)
+		}
+		g.Count++
+		if len(g.SampleAlerts) < 3 {
+			g.SampleAlerts = append(g.SampleAlerts, a.AlertID)
+		}
+		if a.ChannelName != "" && !slices.Contains(g.Channels, a.ChannelName) {
+			g.Channels = append(g.Channels, a.ChannelName)
+		}
+		if a.Incident.IncidentID != "" {
+			g.incidents[a.Incident.IncidentID] = true
+		}
+		if a.EndTime.IsZero() || a.EndTime.Time().After(now) {
+			g.Active++
+			continue
+		}
+		g.Cycles++
+		g.durations = append(g.durations, time.Duration(a.EndTime.Unix()-a.StartTime.Unix())*time.Second)
+	}
+
+	days := window.Hours() / 24
+	for _, g := range order {
+		g.Share = math.Round(float64(g.Count)/float64(len(alerts))*1000) / 1000
+		g.Incidents = len(g.incidents)
+		if days > 0 {
+			g.FlapsPerDay = math.Round(float64(g.Cycles)/days*10) / 10
+		}
+		if len(g.durations) > 0 {
+			slices.Sort(g.durations)
+			g.MedianTTR = formatStatDuration(percentile(g.durations, 50))
+			g.P90TTR = formatStatDuration(percentile(g.durations, 90))
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		if order[i].Count != order[j].Count {
+			return order[i].Count > order[j].Count
+		}
+		return order[i].Cycles > order[j].Cycles
+	})
+	return order
+}
+
+// alertFingerprint returns the labels that identify an alert's source: the
+// given keys, or all labels when none are given.
+func alertFingerprint(labels map[string]string, keys []string) map[string]string {
+	if len(keys) == 0 {
+		return labels
+	}
+	fp := make(map[string]string, len(keys))
+	for _, k := range keys {
+		if v, ok := labels[strings.TrimSpace(k)]; ok {
+			fp[k] = v
+		}
+	}
+	return fp
+}
+
+// formatLabels renders labels as sorted "k=v, k=v".
+func formatLabels(labels map[string]string) string {
+	parts := make([]string, 0, len(labels))
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		parts = append(parts, k+"="+labels[k])
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,61 @@
+package flashduty
+
+import (
+	"testing"
+	"time"
+
+	flashduty "github.com/flashcatcloud/go-flashduty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertNoiseGroupsRanksAndMeasures(t *testing.T) {
+	t.Parallel()
+
+	const start = 1700000000
+	now := time.Unix(start+86400, 0)
+	alert := func(id, title, host string, duration int64, incident string) flashduty.AlertItem {
+		a := flashduty.AlertItem{
+			AlertID:   id,
+			Title:     title,
+			Labels:    map[string]string{"service": "db", "host": host},
+			StartTime: start,
+			Incident:  flashduty.IncidentShort{IncidentID: incident},
+		}
+		if duration > 0 {
+			a.EndTime = flashduty.Timestamp(start + duration)
+		}
+		return a
+	}
+	alerts := []flashduty.AlertItem{
+		alert("a1", "Disk full", "db-01", 0, "i1"),
+		alert("a2", "CPU high", "db-01", 60, ""),
+		alert("a3", "CPU high", "db-02", 120, ""),
+		alert("a4", "CPU high", "db-01", 600, "i2"),
+		alert("a5", "CPU high", "db-01", 90, ""),
+	}
+
+	// By default the whole label set is the fingerprint, so hosts split.
+	groups := alertNoiseGroups(alerts, nil, 2*24*time.Hour, now)
+	require.Len(t, groups, 3)
+	assert.Equal(t, "CPU high", groups[0].Title)
+	assert.Equal(t, 3, groups[0].Count)
+	assert.Equal(t, map[string]string{"service": "db", "host": "db-01"}, groups[0].Labels)
+
+	// Fingerprinting on service folds hosts together.
+	groups = alertNoiseGroups(alerts, []string{"service"}, 2*24*time.Hour, now)
+	require.Len(t, groups, 2)
+	cpu, disk := groups[0], groups[1]
+	assert.Equal(t, 4, cpu.Count)
+	assert.Equal(t, 0.8, cpu.Share)
+	assert.Equal(t, 4, cpu.Cycles)
+	assert.Equal(t, 2.0, cpu.FlapsPerDay)
+	assert.Equal(t, "1m30s", cpu.MedianTTR)
+	assert.Equal(t, "10m0s", cpu.P90TTR)
+	assert.Equal(t, 1, cpu.Incidents)
+	assert.Equal(t, []string{"a2", "a3", "a4"}, cpu.SampleAlerts)
+
+	assert.Equal(t, 1, disk.Active)
+	assert.Equal(t, 0, disk.Cycles)
+	assert.Empty(t, disk.MedianTTR)
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
+	"sync"
 	"testing"
 
 	flashduty "github.com/flashcatcloud/go-flashduty"
@@ -32,6 +34,77 @@ func captureWire(t *testing.T) (*httptest.Server, *string, *map[string]any) {
 	return ts, &gotPath, &gotBody
 }
 
+// fakeRoute returns the `data` payload for one decoded request body, or a
+// fakeResponse to control the whole reply.
+type fakeRoute func(req map[string]any) any
+
+// fakeResponse is written as the complete reply body with its status, for
+// endpoints that answer outside the `data` envelope and for error replies.
+type fakeResponse struct {
+	Status int
+	Body   any
+}
+
+// fakeAPI is a test backend that answers each request path from its route and
+// records the decoded request bodies by path. Unrouted paths fail the test.
+type fakeAPI struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	bodies  map[string][]map[string]any
+	queries map[string]url.Values
+}
+
+func newFakeAPI(t *testing.T, routes map[string]fakeRoute) *fakeAPI {
+	t.Helper()
+	api := &fakeAPI{bodies: map[string][]map[string]any{}, queries: map[string]url.Values{}}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := map[string]any{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		api.mu.Lock()
+		api.bodies[r.URL.Path] = append(api.bodies[r.URL.Path], req)
+		api.queries[r.URL.Path] = r.URL.Query()
+		api.mu.Unlock()
+
+		route, ok := routes[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		status, body := http.StatusOK, route(req)
+		if resp, ok := body.(fakeResponse); ok {
+			status, body = resp.Status, resp.Body
+		} else {
+			body = map[string]any{"data": body}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(api.Close)
+	return api
+}
+
+// requests returns the bodies received on path, oldest first.
+func (api *fakeAPI) requests(path string) []map[string]any {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return api.bodies[path]
+}
+
+// query returns the query parameters of the last request on path.
+func (api *fakeAPI) query(path string) url.Values {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return api.queries[path]
+}
+
+// reply returns a route that always answers with data.
+func reply(data any) fakeRoute {
+	return func(map[string]any) any { return data }
+}
+
 func newTestClients(t *testing.T, baseURL string) GetFlashdutyClientFn {
 	t.Helper()
 	client, err := flashduty.NewClient("test-key", flashduty.WithBaseURL(baseURL))
@@ -43,7 +116,9 @@ func newTestClients(t *testing.T, baseURL string) GetFlashdutyClientFn {
 	}
 }
 
-func callOK(t *testing.T, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), name string, args map[string]any) {
+// callResult runs handler with args and returns its result, failing the test
+// only if the handler itself returns an error.
+func callResult(t *testing.T, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), name string, args map[string]any) *mcp.CallToolResult {
 	t.Helper()
 	result, err := handler(context.Background(), mcp.CallToolRequest{
 		Params: mcp.CallToolParams{Name: name, Arguments: args},
@@ -51,6 +126,12 @@ func callOK(t *testing.T, handler func(context.Context, mcp.CallToolRequest) (*m
 	if err != nil {
 		t.Fatalf("handler returned error: %v", err)
 	}
+	return result
+}
+
+func callOK(t *testing.T, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), name string, args map[string]any) {
+	t.Helper()
+	result := callResult(t, handler, name, args)
 	if result.IsError {
 		txt, _ := mcp.AsTextContent(result.Content[0])
 		t.Fatalf("expected success result, got error: %s", txt.Text)
@@ -0,0 +1,353 @@
+package flashduty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	flashduty "github.com/flashcatcloud/go-flashduty"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
+)
+
+// postmortemMaxFeedPages bounds the timeline pages draft_postmortem reads, so
+// a storm of notification entries cannot turn one call into dozens.
+const postmortemMaxFeedPages = 5
+
+const draftPostmortemDescription = `Draft a post-mortem for one incident. Gathers the incident, its full timeline (with actor names resolved), its alerts and the changes in its channel during the lookback window before it started, and returns a markdown document with Summary, Impact, Root cause, Resolution, Timeline, Triggering alerts and Preceding changes sections. Refine it, then write root_cause/resolution/impact back with update_incident.`
+
+// DraftPostmortem creates a tool that assembles a post-mortem draft
+func DraftPostmortem(getClient GetFlashdutyClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("draft_postmortem",
+			mcp.WithDescription(t("TOOL_DRAFT_POSTMORTEM_DESCRIPTION", draftPostmortemDescription)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DRAFT_POSTMORTEM_USER_TITLE", "Draft post-mortem"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("incident_id", mcp.Required(), mcp.Description("The incident to write the post-mortem for.")),
+			mcp.WithString("lookback", mcp.Description("How far before the incident started to look for changes in its channel, as a duration (\"2h\", \"1d\"). Default 6h, max 31d.")),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Flashduty client: %w", err)
+			}
+
+			incidentID, err := RequiredParam[string](request, "incident_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			lookback, err := optionalLookback(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			inc, _, err := client.New.Incidents.Info(ctx, &flashduty.IncidentInfoRequest{IncidentID: incidentID})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve incident: %v", err)), nil
+			}
+
+			// Everything after the incident itself is best effort: a section
+			// that cannot be loaded says so, and the rest of the draft stands.
+			d := &postmortemDraft{incident: inc, lookback: lookback}
+			d.feed, d.feedErr = incidentFeed(ctx, client, incidentID)
+			d.alerts, d.alertsErr = incidentAlerts(ctx, client, incidentID)
+			d.changes, d.changesErr = precedingChanges(ctx, client, inc.ChannelID, inc.StartTime.Unix(), lookback)
+
+			personIDs := map[int64]bool{}
+			for _, item := range d.feed {
+				if item.CreatorID > 0 {
+					personIDs[item.CreatorID] = true
+				}
+				for _, id := range feedPersonIDs(item) {
+					personIDs[id] = true
+				}
+			}
+			for _, r := range inc.Responders {
+				personIDs[r.PersonID] = true
+			}
+			d.names = lookupPersonNames(ctx, client, sortedIDs(personIDs))
+
+			// The draft is the artifact the agent edits, so it is returned as
+			// markdown whatever the session's output format.
+			return mcp.NewToolResultText(d.render()), nil
+		}
+}
+
+// incidentFeed reads an incident's timeline oldest first.
+func incidentFeed(ctx context.Context, client *Clients, incidentID string) ([]flashduty.IncidentFeedItem, error) {
+	var items []flashduty.IncidentFeedItem
+	for page := 1; page <= postmortemMaxFeedPages; page++ {
+		req := &flashduty.ListIncidentFeedRequest{IncidentID: incidentID, Asc: true}
+		req.Limit = 100
+		if page > 1 {
+			req.Page = page
+		}
+		out, _, err := client.New.Incidents.Feed(ctx, req)
+		if err != nil {
+			return items, err
+		}
+		items = append(items, out.Items...)
+		if !out.HasNextPage {
+			break
+		}
+	}
+	return items, nil
+}
+
+// incidentAlerts reads the first page of an incident's alerts.
+func incidentAlerts(ctx context.Context, client *Clients, incidentID string) (*flashduty.ListIncidentAlertsResponse, error) {
+	req := &flashduty.ListIncidentAlertsRequest{IncidentID: incidentID}
+	req.Limit = 100
+	out, _, err := client.New.Incidents.AlertList(ctx, req)
+	return out, err
+}
+
+// feedPersonIDs returns the people an assignment-style timeline entry names.
+func feedPersonIDs(item flashduty.IncidentFeedItem) []int64 {
+	detail, ok := item.Detail.(map[string]any)
+	if !ok {
+		return nil
+	}
+	var ids []int64
+	for _, key := range []string{"person_ids", "to"} {
+		list, _ := detail[key].([]any)
+		for _, v := range list {
+			if f, ok := v.(float64); ok && f > 0 {
+				ids = append(ids, int64(f))
+			}
+		}
+	}
+	return ids
+}
+
+// feedEventLabels names the timeline entry types a post-mortem reader cares
+// about; other types are shown by their raw code.
+var feedEventLabels = map[flashduty.IncidentFeedType]string{
+	flashduty.IncidentFeedTypeINew:       "Created",
+	flashduty.IncidentFeedTypeIAssign:    "Assigned",
+	flashduty.IncidentFeedTypeIARspd:     "Responder added",
+	flashduty.IncidentFeedTypeINotify:    "Notified",
+	flashduty.IncidentFeedTypeISnooze:    "Snoozed",
+	flashduty.IncidentFeedTypeIWake:      "Woken",
+	flashduty.IncidentFeedTypeIAck:       "Acknowledged",
+	flashduty.IncidentFeedTypeIUnack:     "Unacknowledged",
+	flashduty.IncidentFeedTypeIComm:      "Comment",
+	flashduty.IncidentFeedTypeIRslv:      "Resolved",
+	flashduty.IncidentFeedTypeIReopen:    "Reopened",
+	flashduty.IncidentFeedTypeIMerge:     "Merged",
+	flashduty.IncidentFeedTypeIRTitle:    "Title changed",
+	flashduty.IncidentFeedTypeIRDesc:     "Description changed",
+	flashduty.IncidentFeedTypeIRImpact:   "Impact updated",
+	flashduty.IncidentFeedTypeIRRc:       "Root cause updated",
+	flashduty.IncidentFeedTypeIRRsltn:    "Resolution updated",
+	flashduty.IncidentFeedTypeIRSeverity: "Severity changed",
+	flashduty.IncidentFeedTypeIRField:    "Field updated",
+	flashduty.IncidentFeedTypeICustom:    "Custom action",
+	flashduty.IncidentFeedTypeIWrCreate:  "War room created",
+}
+
+type postmortemDraft struct {
+	incident *flashduty.IncidentInfo
+	lookback time.Duration
+	names    map[int64]string
+
+	feed       []flashduty.IncidentFeedItem
+	feedErr    error
+	alerts     *flashduty.ListIncidentAlertsResponse
+	alertsErr  error
+	changes    *flashduty.ListChangeResponse
+	changesErr error
+}
+
+func (d *postmortemDraft) person(id int64) string {
+	if id == 0 {
+		return "System"
+	}
+	if name, ok := d.names[id]; ok && name != "" {
+		return name
+	}
+	return fmt.Sprintf("person %d", id)
+}
+
+func (d *postmortemDraft) render() string {
+	inc := d.incident
+	var b strings.Builder
+
+	title := inc.Title
+	if inc.Num != "" {
+		title += " (#" + inc.Num + ")"
+	}
+	fmt.Fprintf(&b, "# Post-mortem: %s\n\n", title)
+
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| Field | Value |\n| --- | --- |\n")
+	row := func(k, v string) {
+		if v != "" {
+			fmt.Fprintf(&b, "| %s | %s |\n", k, markdownEscape(v))
+		}
+	}
+	row("Incident", inc.IncidentID)
+	row("Link", inc.DetailURL)
+	row("Severity", inc.IncidentSeverity)
+	row("Status", inc.Progress)
+	if inc.ChannelID != 0 {
+		row("Channel", fmt.Sprintf("%s (%d)", inc.ChannelName, inc.ChannelID))
+	}
+	row("Started", formatInstant(inc.StartTime))
+	if !inc.AckTime.IsZero() {
+		row("Acknowledged", fmt.Sprintf("%s (after %s)", formatInstant(inc.AckTime), elapsedBetween(inc.StartTime, inc.AckTime)))
+	}
+	if !inc.CloseTime.IsZero() {
+		row("Closed", fmt.Sprintf("%s (after %s)", formatInstant(inc.CloseTime), elapsedBetween(inc.StartTime, inc.CloseTime)))
+	}
+	responders := make([]string, 0, len(inc.Responders))
+	for _, r := range inc.Responders {
+		name := r.PersonName
+		if name == "" {
+			name = d.person(r.PersonID)
+		}
+		responders = append(responders, name)
+	}
+	row("Responders", strings.Join(responders, ", "))
+	if d.alerts != nil {
+		row("Alerts", fmt.Sprintf("%d", d.alerts.Total))
+	}
+	if inc.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", inc.Description)
+	}
+
+	section := func(heading, body string) {
+		if strings.TrimSpace(body) == "" {
+			body = "_To be written._"
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n%s\n", heading, body)
+	}
+	section("Impact", inc.Impact)
+	section("Root cause", inc.RootCause)
+	section("Resolution", inc.Resolution)
+
+	b.WriteString("\n## Timeline\n\n")
+	switch {
+	case d.feedErr != nil && len(d.feed) == 0:
+		fmt.Fprintf(&b, "_Could not load the timeline: %v_\n", d.feedErr)
+	case len(d.feed) == 0:
+		b.WriteString("_No timeline entries._\n")
+	default:
+		b.WriteString("| Time | Actor | Event | Details |\n| --- | --- | --- | --- |\n")
+		for _, item := range d.feed {
+			if item.Type == flashduty.IncidentFeedTypeINotify {
+				// Per-channel notification receipts drown the human actions.
+				continue
+			}
+			label, ok := feedEventLabels[item.Type]
+			if !ok {
+				label = string(item.Type)
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n",
+				item.CreatedAt.Time().Format(time.RFC3339),
+				markdownEscape(d.person(item.CreatorID)),
+				label,
+				markdownEscape(d.feedDetail(item)))
+		}
+		if d.feedErr != nil {
+			fmt.Fprintf(&b, "\n_The timeline is incomplete: %v_\n", d.feedErr)
+		}
+	}
+
+	b.WriteString("\n## Triggering alerts\n\n")
+	switch {
+	case d.alertsErr != nil:
+		fmt.Fprintf(&b, "_Could not load alerts: %v_\n", d.alertsErr)
+	case len(d.alerts.Items) == 0:
+		b.WriteString("_No alerts._\n")
+	default:
+		b.WriteString("| Started | Severity | Status | Title | Labels |\n| --- | --- | --- | --- | --- |\n")
+		for _, a := range d.alerts.Items {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+				formatInstant(a.StartTime), a.AlertSeverity, a.AlertStatus,
+				markdownEscape(a.Title), markdownEscape(formatLabels(a.Labels)))
+		}
+		if int(d.alerts.Total) > len(d.alerts.Items) {
+			fmt.Fprintf(&b, "\n_Showing %d of %d alerts; see query_incident_alerts for the rest._\n", len(d.alerts.Items), d.alerts.Total)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## Changes in the %s before the incident\n\n", d.lookback)
+	switch {
+	case d.changesErr != nil:
+		fmt.Fprintf(&b, "_Could not load changes: %v_\n", d.changesErr)
+	case len(d.changes.Items) == 0:
+		b.WriteString("_No changes recorded in the channel during this window._\n")
+	default:
+		b.WriteString("| Started | Before incident | Title | Status | Link |\n| --- | --- | --- | --- | --- |\n")
+		// Listed newest first; the timeline reads oldest first.
+		for _, c := range slices.Backward(d.changes.Items) {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+				formatInstant(c.StartTime), elapsedBetween(c.StartTime, inc.StartTime),
+				markdownEscape(c.Title), c.ChangeStatus, markdownEscape(c.Link))
+		}
+		if int(d.changes.Total) > len(d.changes.Items) {
+			fmt.Fprintf(&b, "\n_Showing %d of %d changes; narrow lookback or see query_changes for the older ones._\n", len(d.changes.Items), d.changes.Total)
+		}
+	}
+
+	return b.String()
+}
+
+// feedDetail summarises a timeline entry's payload in one line.
+func (d *postmortemDraft) feedDetail(item flashduty.IncidentFeedItem) string {
+	detail, ok := item.Detail.(map[string]any)
+	if !ok || len(detail) == 0 {
+		return ""
+	}
+	switch item.Type {
+	case flashduty.IncidentFeedTypeIComm:
+		s, _ := detail["comment"].(string)
+		return s
+	case flashduty.IncidentFeedTypeIAssign, flashduty.IncidentFeedTypeIARspd:
+		var people []string
+		for _, id := range feedPersonIDs(item) {
+			people = append(people, d.person(id))
+		}
+		s := strings.Join(people, ", ")
+		if rule, _ := detail["escalate_rule_name"].(string); rule != "" {
+			if s != "" {
+				s += " "
+			}
+			s += "via " + rule
+		}
+		return s
+	}
+	data, err := json.Marshal(detail)
+	if err != nil {
+		return ""
+	}
+	const maxDetail = 200
+	if s := string(data); len(s) > maxDetail {
+		// Cut on a rune boundary so non-ASCII text stays valid UTF-8.
+		cut := maxDetail
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		return s[:cut] + "…"
+	}
+	return string(data)
+}
+
+func formatInstant(t flashduty.Timestamp) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Time().Format(time.RFC3339)
+}
+
+// elapsedBetween renders the time from a to b, rounded to the minute.
+func elapsedBetween(a, b flashduty.Timestamp) string {
+	return (time.Duration(b.Unix()-a.Unix()) * time.Second).Round(time.Minute).String()
+}
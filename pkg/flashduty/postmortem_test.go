@@ -0,0 +1,104 @@
+package flashduty
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	flashduty "github.com/flashcatcloud/go-flashduty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
+)
+
+func TestDraftPostmortemAssemblesSections(t *testing.T) {
+	t.Parallel()
+
+	const start = 1700000000
+	api := newFakeAPI(t, map[string]fakeRoute{
+		"/incident/info": reply(map[string]any{
+			"incident_id": "a1", "num": "42", "title": "DB latency", "incident_severity": "Critical",
+			"progress": "Closed", "channel_id": 3, "channel_name": "payments",
+			"start_time": start, "ack_time": start + 300, "close_time": start + 3600,
+			"root_cause": "Index dropped by migration",
+		}),
+		"/incident/feed": func(req map[string]any) any {
+			assert.Equal(t, true, req["asc"])
+			return map[string]any{"items": []any{
+				map[string]any{"type": "i_new", "creator_id": 0, "created_at": start * 1000},
+				map[string]any{"type": "i_notify", "creator_id": 0, "created_at": start * 1000},
+				map[string]any{"type": "i_assign", "creator_id": 0, "created_at": start * 1000, "detail": map[string]any{"person_ids": []any{7}}},
+				map[string]any{"type": "i_ack", "creator_id": 7, "created_at": (start + 300) * 1000},
+				map[string]any{"type": "i_comm", "creator_id": 7, "created_at": (start + 600) * 1000, "detail": map[string]any{"comment": "rolling back"}},
+			}}
+		},
+		"/person/infos": reply(map[string]any{"items": []any{map[string]any{"person_id": 7, "person_name": "Alice"}}}),
+		"/incident/alert/list": reply(map[string]any{"total": 1, "items": []any{map[string]any{
+			"title": "p99 > 2s", "alert_severity": "Critical", "alert_status": "Ok",
+			"start_time": start - 60, "labels": map[string]any{"service": "db", "env": "prod"},
+		}}}),
+		"/change/list": func(req map[string]any) any {
+			assert.Equal(t, []any{3.0}, req["channel_ids"])
+			assert.Equal(t, float64(start-2*3600), req["start_time"])
+			assert.Equal(t, float64(start), req["end_time"])
+			return map[string]any{"total": 1, "items": []any{map[string]any{
+				"title": "Deploy schema v12", "change_status": "Done", "start_time": start - 900,
+				"link": "https://ci.example.com/run?a=1|2",
+			}}}
+		},
+	})
+	tool, handler := DraftPostmortem(newTestClients(t, api.URL), translations.NullTranslationHelper)
+	require.True(t, *tool.Annotations.ReadOnlyHint)
+
+	res := callResult(t, handler, "draft_postmortem", map[string]any{"incident_id": "a1", "lookback": "2h"})
+	out := resultText(t, res)
+
+	assert.Contains(t, out, "# Post-mortem: DB latency (#42)")
+	assert.Contains(t, out, "| Acknowledged |")
+	assert.Contains(t, out, "(after 5m0s)")
+	assert.Contains(t, out, "(after 1h0m0s)")
+	assert.Contains(t, out, "## Impact\n\n_To be written._")
+	assert.Contains(t, out, "## Root cause\n\nIndex dropped by migration")
+	assert.Contains(t, out, "| System | Assigned | Alice |")
+	assert.Contains(t, out, "| Alice | Comment | rolling back |")
+	assert.NotContains(t, out, "i_notify", "notification receipts are left out")
+	assert.Contains(t, out, "| p99 > 2s | env=prod, service=db |")
+	assert.Contains(t, out, "## Changes in the 2h0m0s before the incident")
+	assert.Contains(t, out, "| 15m0s | Deploy schema v12 | Done | https://ci.example.com/run?a=1\\|2 |")
+}
+
+func TestDraftPostmortemDegradesMissingSections(t *testing.T) {
+	t.Parallel()
+
+	boom := reply(fakeResponse{Status: http.StatusInternalServerError, Body: map[string]any{"error": map[string]any{"code": "Internal", "message": "boom"}}})
+	api := newFakeAPI(t, map[string]fakeRoute{
+		"/incident/info":       reply(map[string]any{"incident_id": "a1", "title": "Orphan"}),
+		"/incident/feed":       boom,
+		"/incident/alert/list": boom,
+		"/change/list":         boom,
+	})
+	_, handler := DraftPostmortem(newTestClients(t, api.URL), translations.NullTranslationHelper)
+
+	res := callResult(t, handler, "draft_postmortem", map[string]any{"incident_id": "a1"})
+	out := resultText(t, res)
+	assert.Contains(t, out, "# Post-mortem: Orphan")
+	assert.Contains(t, out, "_Could not load the timeline:")
+	assert.Contains(t, out, "_Could not load alerts:")
+	assert.Contains(t, out, "_Could not load changes:")
+
+	res = callResult(t, handler, "draft_postmortem", map[string]any{"incident_id": "a1", "lookback": "40d"})
+	assert.True(t, res.IsError)
+}
+
+func TestFeedDetailTruncatesOnRuneBoundary(t *testing.T) {
+	t.Parallel()
+
+	// 198 bytes of JSON framing and ASCII put a 3-byte rune across byte 200.
+	detail := map[string]any{"note": strings.Repeat("x", 189) + strings.Repeat("数据库", 20)}
+	got := (&postmortemDraft{}).feedDetail(flashduty.IncidentFeedItem{Type: "i_r_title", Detail: detail})
+	assert.True(t, utf8.ValidString(got), "detail %q is not valid UTF-8", got)
+	assert.True(t, strings.HasSuffix(got, "…"))
+	assert.LessOrEqual(t, len(strings.TrimSuffix(got, "…")), 200)
+}
@@ -0,0 +1,151 @@
+package flashduty
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// FieldsDescription is the canonical description for the `fields` parameter on
+// list-shaped tools.
+const FieldsDescription = "Comma-separated dotted paths of item fields to return, e.g. `incident_id,title,incident_severity,responders.person_id`; a path through a list applies to every element. " +
+	"Omit to return whole items. `total`, `truncated` and `hint` are always returned."
+
+// withFieldsParam declares the `fields` parameter.
+func withFieldsParam() mcp.ToolOption {
+	return mcp.WithString("fields", mcp.Description(FieldsDescription))
+}
+
+// projection is a parsed `fields` argument: a tree of the JSON keys to keep,
+// where an empty subtree keeps the whole value. A nil projection keeps
+// everything.
+type projection map[string]projection
+
+// optionalProjection parses the `fields` argument, returning nil when it is
+// absent or empty.
+func optionalProjection(r mcp.CallToolRequest) projection {
+	fields, _ := OptionalParam[string](r, "fields")
+	return newProjection(fields)
+}
+
+// newProjection parses a comma-separated list of dotted paths.
+func newProjection(fields string) projection {
+	var p projection
+	for _, path := range parseCommaSeparatedStrings(fields) {
+		if p == nil {
+			p = projection{}
+		}
+		p.add(strings.Split(path, "."))
+	}
+	return p
+}
+
+func (p projection) add(keys []string) {
+	node := p
+	for i, key := range keys {
+		if key == "" {
+			return
+		}
+		child, ok := node[key]
+		if ok && len(child) == 0 {
+			// A shorter path already keeps this whole value.
+			return
+		}
+		if i == len(keys)-1 {
+			node[key] = projection{}
+			return
+		}
+		if !ok {
+			child = projection{}
+			node[key] = child
+		}
+		node = child
+	}
+}
+
+// apply narrows v, an item or a list of items, to the projected fields. Items
+// are converted to their JSON form first, so paths use the API's field names
+// and the result marshals identically as JSON or TOON. Integers are decoded
+// as int64 so large IDs survive the round trip. v is returned unchanged when p
+// is nil or v cannot be converted.
+func (p projection) apply(v any) any {
+	if len(p) == 0 {
+		return v
+	}
+	generic, err := toGeneric(v)
+	if err != nil {
+		return v
+	}
+	return p.project(generic)
+}
+
+// toGeneric converts v to its JSON form as maps, slices and scalars, with
+// integers as int64 so large IDs survive the round trip.
+func toGeneric(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return fromJSONNumbers(generic), nil
+}
+
+// fromJSONNumbers replaces the json.Numbers in v with int64, or float64 for
+// numbers that are not integers, since the TOON encoder would otherwise
+// format them through float64 and round IDs above 2^53.
+func fromJSONNumbers(v any) any {
+	switch val := v.(type) {
+	case json.Number:
+		if n, err := val.Int64(); err == nil {
+			return n
+		}
+		f, _ := val.Float64()
+		return f
+	case map[string]any:
+		for key, field := range val {
+			val[key] = fromJSONNumbers(field)
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			val[i] = fromJSONNumbers(item)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+func (p projection) project(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(p))
+		for key, sub := range p {
+			field, ok := val[key]
+			if !ok {
+				continue
+			}
+			if len(sub) == 0 {
+				out[key] = field
+			} else {
+				out[key] = sub.project(field)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = p.project(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,136 @@
+package flashduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
+)
+
+func TestProjectionApply(t *testing.T) {
+	t.Parallel()
+
+	type responder struct {
+		PersonID   uint64 `json:"person_id"`
+		AssignedAt int64  `json:"assigned_at"`
+	}
+	type item struct {
+		IncidentID string      `json:"incident_id"`
+		Title      string      `json:"title"`
+		Detail     string      `json:"detail"`
+		Responders []responder `json:"responders"`
+		Labels     map[string]string
+	}
+	items := []item{{
+		IncidentID: "i1",
+		Title:      "disk full",
+		Detail:     "long text",
+		Responders: []responder{{PersonID: 9007199254740993, AssignedAt: 1}},
+		Labels:     map[string]string{"a": "b"},
+	}}
+
+	tests := []struct {
+		name   string
+		fields string
+		want   string
+	}{
+		{"absent keeps everything", "", `[{"incident_id":"i1","title":"disk full","detail":"long text","responders":[{"person_id":9007199254740993,"assigned_at":1}],"Labels":{"a":"b"}}]`},
+		{"top-level and nested through a list", " incident_id, responders.person_id ,", `[{"incident_id":"i1","responders":[{"person_id":9007199254740993}]}]`},
+		{"shorter path wins", "responders.person_id,responders", `[{"responders":[{"assigned_at":1,"person_id":9007199254740993}]}]`},
+		{"unknown paths are skipped", "nope,title.deeper", `[{"title":"disk full"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"fields": tt.fields}}}
+			got, err := json.Marshal(optionalProjection(request).apply(items))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotV, wantV any
+			_ = json.Unmarshal(got, &gotV)
+			_ = json.Unmarshal([]byte(tt.want), &wantV)
+			if !reflect.DeepEqual(gotV, wantV) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+			if tt.fields != "" && strings.Contains(tt.want, "9007199254740993") && !strings.Contains(string(got), "9007199254740993") {
+				t.Errorf("large ID lost precision: %s", got)
+			}
+		})
+	}
+}
+
+// TestQueryIncidentsFieldsKeepsEnvelope verifies projection narrows the items
+// but keeps the total/truncated/hint envelope, in both output formats.
+func TestQueryIncidentsFieldsKeepsEnvelope(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"items": []any{map[string]any{
+					"incident_id":       "i1",
+					"title":             "disk full",
+					"incident_severity": "Critical",
+					"description":       "long text",
+					"responders":        []any{map[string]any{"person_id": 12345678901234567, "assigned_at": 1}},
+				}},
+				"total": 5,
+			},
+		})
+	}))
+	defer ts.Close()
+
+	_, handler := QueryIncidents(newTestClients(t, ts.URL), translations.NullTranslationHelper)
+	call := func(format OutputFormat) *mcp.CallToolResult {
+		result, err := handler(ContextWithOutputFormat(context.Background(), format), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name: "query_incidents",
+				Arguments: map[string]any{
+					"since": "7d", "until": "now", "limit": float64(1),
+					"fields": "incident_id,title,responders.person_id",
+				},
+			},
+		})
+		if err != nil || result.IsError {
+			t.Fatalf("unexpected failure: err=%v result=%+v", err, result)
+		}
+		return result
+	}
+
+	result := call(OutputFormatJSON)
+	if text := resultText(t, result); !strings.Contains(text, `"person_id":12345678901234567`) {
+		t.Errorf("large ID lost precision in JSON:\n%s", text)
+	}
+	res := decodeResult(t, result)
+	if res["total"] != float64(5) || res["truncated"] != true || res["hint"] == nil {
+		t.Errorf("envelope not kept: %v", res)
+	}
+	incident := res["incidents"].([]any)[0].(map[string]any)
+	want := map[string]any{
+		"incident_id": "i1",
+		"title":       "disk full",
+		"responders":  []any{map[string]any{"person_id": float64(12345678901234567)}},
+	}
+	if !reflect.DeepEqual(incident, want) {
+		t.Errorf("incident = %v, want %v", incident, want)
+	}
+
+	// The projected envelope also encodes as TOON, large IDs included.
+	text := resultText(t, call(OutputFormatTOON))
+	for _, want := range []string{"incident_id: i1", "responders[1]{person_id}:", "12345678901234567", "total: 5", "truncated: true"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("TOON output lacks %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "long text") {
+		t.Errorf("TOON output was not projected:\n%s", text)
+	}
+}
@@ -0,0 +1,78 @@
+package flashduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// pushResponse is the envelope the integration push endpoints reply with.
+type pushResponse struct {
+	RequestID string          `json:"request_id"`
+	Data      json.RawMessage `json:"data"`
+	Error     *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// pushEvent posts body to an integration push endpoint such as
+// "/event/push/change/standard". Pushes are authenticated by the
+// integration's key, not the APP key, so they go around the SDK to the same
+// base URL.
+func pushEvent(ctx context.Context, client *Clients, path, integrationKey string, body any) (*pushResponse, error) {
+	u := *client.New.BaseURL
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	u.RawQuery = url.Values{"integration_key": {integrationKey}}.Encode()
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("encoding event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if client.New.UserAgent != "" {
+		req.Header.Set("User-Agent", client.New.UserAgent)
+	}
+
+	httpClient := client.Push
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		// The URL carries the integration key; keep it out of the error.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	var out pushResponse
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("unexpected response (HTTP %d)", resp.StatusCode)
+	}
+	if out.Error != nil && (out.Error.Code != "" || out.Error.Message != "") {
+		return nil, fmt.Errorf("%s: %s", out.Error.Code, out.Error.Message)
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return &out, nil
+}
@@ -0,0 +1,77 @@
+package flashduty
+
+import (
+	"context"
+	"encoding/json"
+
+	flashduty "github.com/flashcatcloud/go-flashduty"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CacheDescription is the canonical description for the `cache` parameter on
+// tools whose lookups are served from the reference-data cache.
+const CacheDescription = "Set to \"bypass\" to skip the server's short-lived cache of members, teams, channels and fields and fetch fresh data, e.g. right after a change."
+
+// withCacheParam declares the `cache` parameter.
+func withCacheParam() mcp.ToolOption {
+	return mcp.WithString("cache", mcp.Description(CacheDescription), mcp.Enum("bypass"))
+}
+
+// cacheBypassed reports whether the tool call asked for fresh data.
+func cacheBypassed(r mcp.CallToolRequest) bool {
+	v, _ := OptionalParam[string](r, "cache")
+	return v == "bypass"
+}
+
+// cachedLookup runs load through the client's reference cache under a key
+// built from kind and the API request. Cached values are shared between tool
+// calls, so callers must treat them as read-only.
+func cachedLookup[T any](ctx context.Context, client *Clients, refresh bool, kind string, req any, load func() (T, error)) (T, error) {
+	if client.Cache == nil {
+		return load()
+	}
+	key, err := json.Marshal(req)
+	if err != nil {
+		return load()
+	}
+	v, err := client.Cache.Get(ctx, kind+":"+string(key), refresh, func() (any, error) {
+		return load()
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v.(T), nil
+}
+
+// personInfos looks up member profiles by ID through the reference cache.
+func personInfos(ctx context.Context, client *Clients, refresh bool, req *flashduty.PersonInfosRequest) (*flashduty.PersonInfosResponse, error) {
+	return cachedLookup(ctx, client, refresh, "person_infos", req, func() (*flashduty.PersonInfosResponse, error) {
+		out, _, err := client.New.Members.PersonInfos(ctx, req)
+		return out, err
+	})
+}
+
+// teamInfos looks up teams by ID through the reference cache.
+func teamInfos(ctx context.Context, client *Clients, refresh bool, req *flashduty.TeamInfosRequest) (*flashduty.TeamInfosResponse, error) {
+	return cachedLookup(ctx, client, refresh, "team_infos", req, func() (*flashduty.TeamInfosResponse, error) {
+		out, _, err := client.New.Teams.ReadInfos(ctx, req)
+		return out, err
+	})
+}
+
+// channelList lists channels through the reference cache.
+func channelList(ctx context.Context, client *Clients, refresh bool, req *flashduty.ListChannelsRequest) (*flashduty.ListChannelsResponse, error) {
+	return cachedLookup(ctx, client, refresh, "channel_list", req, func() (*flashduty.ListChannelsResponse, error) {
+		out, _, err := client.New.Channels.ChannelList(ctx, req)
+		return out, err
+	})
+}
+
+// fieldList lists custom field definitions through the reference cache.
+func fieldList(ctx context.Context, client *Clients, refresh bool, req *flashduty.FieldListRequest) (*flashduty.FieldListResponse, error) {
+	return cachedLookup(ctx, client, refresh, "field_list", req, func() (*flashduty.FieldListResponse, error) {
+		out, _, err := client.New.AlertEnrichment.FieldReadList(ctx, req)
+		return out, err
+	})
+}
@@ -0,0 +1,272 @@
+package flashduty
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	flashduty "github.com/flashcatcloud/go-flashduty"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/internal/timeutil"
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
+)
+
+// maxStatsWindow bounds incident_stats. The backend keeps roughly 90 days of
+// incidents, so a longer window only adds empty chunks.
+const maxStatsWindow = 92 * 24 * time.Hour
+
+// maxStatsIncidents caps how many incidents one incident_stats call reads, so
+// a busy account cannot turn it into hundreds of list requests.
+const maxStatsIncidents = 10000
+
+const statsGroupAll = "(all)"
+
+// incidentStatsSinceDescription lifts the 31-day limit SinceDescription
+// advertises, since incident_stats splits long windows itself.
+const incidentStatsSinceDescription = SinceDescription +
+	" For this tool the 31-day limit does not apply: windows up to 92 days are split automatically."
+
+const incidentStatsDescription = `Aggregate incident metrics over a time window: count, acknowledged and closed counts, MTTA (start to acknowledgement) and MTTR (start to close) as mean, p50 and p90, grouped by channel, severity, responder or day. Pages through every matching incident server-side, so prefer this over query_incidents for "how many" and "how fast" questions. The first row is the "(all)" total. With group_by=responder an incident counts toward each of its responders. Windows up to 92 days are split into 31-day backend queries.`
+
+// IncidentStats creates a tool that aggregates incident counts and response
+// times
+func IncidentStats(getClient GetFlashdutyClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("incident_stats",
+			mcp.WithDescription(t("TOOL_INCIDENT_STATS_DESCRIPTION", incidentStatsDescription)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_INCIDENT_STATS_USER_TITLE", "Incident statistics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithSince(mcp.Required(), mcp.Description(incidentStatsSinceDescription)),
+			WithUntil(),
+			mcp.WithString("group_by", mcp.Description("Dimension to group by. Default channel. Days are UTC calendar dates of the incident start."), mcp.Enum("channel", "severity", "responder", "day")),
+			mcp.WithString("channel_ids", mcp.Description("Comma-separated collaboration space IDs to filter by.")),
+			mcp.WithString("severity", mcp.Description("Filter by severity level. Valid values: Info, Warning, Critical."), mcp.Enum("Info", "Warning", "Critical")),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Flashduty client: %w", err)
+			}
+
+			args := request.GetArguments()
+			groupBy, _ := OptionalParam[string](request, "group_by")
+			if groupBy == "" {
+				groupBy = "channel"
+			}
+			keys, ok := statsGroupers[groupBy]
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid group_by %q: use channel, severity, responder or day", groupBy)), nil
+			}
+			severity, _ := OptionalParam[string](request, "severity")
+			channelIdsStr, _ := OptionalParam[string](request, "channel_ids")
+
+			startTime, err := timeutil.ParseAny(args["since"])
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid since: %v", err)), nil
+			}
+			endTime, err := parseUntilArg(args["until"])
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid until: %v", err)), nil
+			}
+			if startTime <= 0 {
+				return mcp.NewToolResultError("since is required"), nil
+			}
+			if startTime >= endTime {
+				return mcp.NewToolResultError(fmt.Sprintf("since (%d) must be earlier than until (%d); did you swap them?", startTime, endTime)), nil
+			}
+			if window := time.Duration(endTime-startTime) * time.Second; window > maxStatsWindow {
+				return mcp.NewToolResultError(fmt.Sprintf("window of %s exceeds the 92-day max; older incidents have been purged", window.Round(time.Hour))), nil
+			}
+
+			req := flashduty.ListIncidentsRequest{IncidentSeverity: severity}
+			if channelIdsStr != "" {
+				channelIDs := parseCommaSeparatedInts(channelIdsStr)
+				if len(channelIDs) == 0 {
+					return mcp.NewToolResultError("channel_ids must contain at least one valid ID when specified"), nil
+				}
+				req.ChannelIDs = make([]int64, len(channelIDs))
+				for i, id := range channelIDs {
+					req.ChannelIDs[i] = int64(id)
+				}
+			}
+
+			incidents, complete, err := listAllIncidents(ctx, client, req, startTime, endTime)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve incidents: %v", err)), nil
+			}
+
+			result := map[string]any{
+				"group_by": groupBy,
+				"rows":     incidentStatsRows(incidents, groupBy, keys),
+			}
+			if !complete {
+				result["truncated"] = true
+				result["hint"] = fmt.Sprintf("Stopped after %d incidents; the figures cover only those. Narrow the window or filter by channel_ids/severity for exact numbers.", len(incidents))
+			}
+			return MarshalResult(ctx, result), nil
+		}
+}
+
+// listAllIncidents pages through every incident that started in
+// [start, end), issuing one query per MaxTimeWindow chunk. complete is false
+// when maxStatsIncidents was reached first.
+func listAllIncidents(ctx context.Context, client *Clients, filter flashduty.ListIncidentsRequest, start, end int64) (incidents []flashduty.IncidentInfo, complete bool, err error) {
+	chunk := int64(MaxTimeWindow / time.Second)
+	for from := start; from < end; from += chunk {
+		req := filter
+		req.StartTime, req.EndTime = from, min(from+chunk, end)
+		req.Limit = 100
+		for page := 1; ; page++ {
+			req.Page = page
+			out, _, err := client.New.Incidents.List(ctx, &req)
+			if err != nil {
+				return nil, false, err
+			}
+			incidents = append(incidents, out.Items...)
+			if len(incidents) >= maxStatsIncidents {
+				return incidents[:maxStatsIncidents], false, nil
+			}
+			if !out.HasNextPage || len(out.Items) == 0 {
+				break
+			}
+			req.SearchAfterCtx = out.SearchAfterCtx
+		}
+	}
+	return incidents, true, nil
+}
+
+// statsGroupers maps each group_by value to the group keys of an incident.
+var statsGroupers = map[string]func(flashduty.IncidentInfo) []string{
+	"channel": func(inc flashduty.IncidentInfo) []string {
+		if inc.ChannelID == 0 {
+			return []string{"(no channel)"}
+		}
+		if inc.ChannelName == "" {
+			return []string{fmt.Sprintf("channel %d", inc.ChannelID)}
+		}
+		return []string{inc.ChannelName}
+	},
+	"severity": func(inc flashduty.IncidentInfo) []string {
+		return []string{inc.IncidentSeverity}
+	},
+	"responder": func(inc flashduty.IncidentInfo) []string {
+		if len(inc.Responders) == 0 {
+			return []string{"(unassigned)"}
+		}
+		keys := make([]string, 0, len(inc.Responders))
+		for _, r := range inc.Responders {
+			name := r.PersonName
+			if name == "" {
+				name = fmt.Sprintf("person %d", r.PersonID)
+			}
+			if !slices.Contains(keys, name) {
+				keys = append(keys, name)
+			}
+		}
+		return keys
+	},
+	"day": func(inc flashduty.IncidentInfo) []string {
+		return []string{inc.StartTime.Time().UTC().Format(time.DateOnly)}
+	},
+}
+
+type incidentStatsGroup struct {
+	count, acked, closed int
+	tta, ttr             []time.Duration
+}
+
+func (g *incidentStatsGroup) add(inc flashduty.IncidentInfo) {
+	g.count++
+	if !inc.AckTime.IsZero() {
+		g.acked++
+		g.tta = append(g.tta, time.Duration(inc.AckTime.Unix()-inc.StartTime.Unix())*time.Second)
+	}
+	if !inc.CloseTime.IsZero() {
+		g.closed++
+		g.ttr = append(g.ttr, time.Duration(inc.CloseTime.Unix()-inc.StartTime.Unix())*time.Second)
+	}
+}
+
+func (g *incidentStatsGroup) row(name string) map[string]any {
+	row := map[string]any{
+		"group":     name,
+		"incidents": g.count,
+		"acked":     g.acked,
+		"closed":    g.closed,
+	}
+	for prefix, ds := range map[string][]time.Duration{"mtta": g.tta, "mttr": g.ttr} {
+		if len(ds) == 0 {
+			continue
+		}
+		slices.Sort(ds)
+		row[prefix] = formatStatDuration(mean(ds))
+		row[prefix+"_p50"] = formatStatDuration(percentile(ds, 50))
+		row[prefix+"_p90"] = formatStatDuration(percentile(ds, 90))
+	}
+	return row
+}
+
+// incidentStatsRows aggregates incidents into one row per group, preceded by
+// the overall total. Days are listed chronologically, other groups busiest
+// first.
+func incidentStatsRows(incidents []flashduty.IncidentInfo, groupBy string, keys func(flashduty.IncidentInfo) []string) []map[string]any {
+	var all incidentStatsGroup
+	groups := map[string]*incidentStatsGroup{}
+	for _, inc := range incidents {
+		all.add(inc)
+		for _, key := range keys(inc) {
+			g, ok := groups[key]
+			if !ok {
+				g = &incidentStatsGroup{}
+				groups[key] = g
+			}
+			g.add(inc)
+		}
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if groupBy != "day" && groups[names[i]].count != groups[names[j]].count {
+			return groups[names[i]].count > groups[names[j]].count
+		}
+		return names[i] < names[j]
+	})
+
+	rows := make([]map[string]any, 0, len(names)+1)
+	rows = append(rows, all.row(statsGroupAll))
+	for _, name := range names {
+		rows = append(rows, groups[name].row(name))
+	}
+	return rows
+}
+
+func mean(ds []time.Duration) time.Duration {
+	var sum time.Duration
+	for _, d := range ds {
+		sum += d
+	}
+	return sum / time.Duration(len(ds))
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted ds.
+func percentile(ds []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(ds))))
+	return ds[max(rank, 1)-1]
+}
+
+// formatStatDuration renders d at a precision that suits its size: seconds
+// under an hour, minutes beyond.
+func formatStatDuration(d time.Duration) string {
+	if d >= time.Hour {
+		return d.Round(time.Minute).String()
+	}
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,102 @@
+package flashduty
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	flashduty "github.com/flashcatcloud/go-flashduty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
+)
+
+func TestIncidentStatsSplitsWindowAndAggregates(t *testing.T) {
+	t.Parallel()
+
+	const since = 1700000000
+	until := since + int64(40*24*time.Hour/time.Second)
+	incident := func(channel string, start, ack, closed int64) map[string]any {
+		return map[string]any{"channel_id": 1, "channel_name": channel, "start_time": start, "ack_time": ack, "close_time": closed}
+	}
+
+	api := newFakeAPI(t, map[string]fakeRoute{
+		"/incident/list": func(req map[string]any) any {
+			switch from := int64(req["start_time"].(float64)); {
+			case from == since && req["p"] == 1.0:
+				return map[string]any{"has_next_page": true, "search_after_ctx": "c1", "items": []any{
+					incident("db", since, since+60, since+600),
+					incident("db", since, since+120, 0),
+				}}
+			case from == since:
+				assert.Equal(t, "c1", req["search_after_ctx"])
+				return map[string]any{"items": []any{incident("db", since, since+600, since+3600)}}
+			default:
+				return map[string]any{"items": []any{incident("web", from, 0, from+1200)}}
+			}
+		},
+	})
+	_, handler := IncidentStats(newTestClients(t, api.URL), translations.NullTranslationHelper)
+
+	res := callResult(t, handler, "incident_stats", map[string]any{"since": "1700000000", "until": strconv.FormatInt(until, 10), "group_by": "channel"})
+
+	var payload struct {
+		Rows []map[string]any `json:"rows"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resultText(t, res)), &payload))
+
+	var windows [][2]int64
+	for _, req := range api.requests("/incident/list") {
+		windows = append(windows, [2]int64{int64(req["start_time"].(float64)), int64(req["end_time"].(float64))})
+	}
+	chunkEnd := since + int64(MaxTimeWindow/time.Second)
+	assert.Equal(t, [][2]int64{{since, chunkEnd}, {since, chunkEnd}, {chunkEnd, until}}, windows)
+
+	require.Len(t, payload.Rows, 3)
+	all, db, web := payload.Rows[0], payload.Rows[1], payload.Rows[2]
+	assert.Equal(t, "(all)", all["group"])
+	assert.Equal(t, 4.0, all["incidents"])
+	assert.Equal(t, 3.0, all["acked"])
+	assert.Equal(t, 3.0, all["closed"])
+
+	assert.Equal(t, "db", db["group"])
+	assert.Equal(t, 3.0, db["incidents"])
+	assert.Equal(t, "4m20s", db["mtta"])
+	assert.Equal(t, "2m0s", db["mtta_p50"])
+	assert.Equal(t, "10m0s", db["mtta_p90"])
+	assert.Equal(t, "35m0s", db["mttr"])
+
+	assert.Equal(t, "web", web["group"])
+	assert.NotContains(t, web, "mtta", "no acknowledged incidents, no MTTA")
+	assert.Equal(t, "20m0s", web["mttr_p90"])
+}
+
+func TestIncidentStatsRejectsOverlongWindow(t *testing.T) {
+	t.Parallel()
+
+	_, handler := IncidentStats(func(ctx context.Context) (context.Context, *Clients, error) {
+		return ctx, &Clients{}, nil
+	}, translations.NullTranslationHelper)
+	res := callResult(t, handler, "incident_stats", map[string]any{"since": "100d"})
+	assert.True(t, res.IsError)
+}
+
+func TestIncidentStatsDaysAreUTC(t *testing.T) {
+	t.Parallel()
+
+	// 23:30 UTC is already the next day east of UTC.
+	inc := flashduty.IncidentInfo{StartTime: flashduty.Timestamp(time.Date(2023, 11, 14, 23, 30, 0, 0, time.UTC).Unix())}
+	assert.Equal(t, []string{"2023-11-14"}, statsGroupers["day"](inc))
+}
+
+func TestPercentileNearestRank(t *testing.T) {
+	t.Parallel()
+
+	ds := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(t, time.Duration(5), percentile(ds, 50))
+	assert.Equal(t, time.Duration(9), percentile(ds, 90))
+	assert.Equal(t, time.Duration(1), percentile(ds[:1], 90))
+}
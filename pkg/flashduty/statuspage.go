@@ -26,6 +26,7 @@ func QueryStatusPages(getClient GetFlashdutyClientFn, t translations.Translation
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("page_ids", mcp.Description("Comma-separated status page IDs for direct lookup. If not provided, returns all pages.")),
+			withFieldsParam(),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			ctx, client, err := getClient(ctx)
 			if err != nil {
@@ -59,8 +60,8 @@ func QueryStatusPages(getClient GetFlashdutyClientFn, t translations.Translation
 				pages = filtered
 			}
 
-			return MarshalResult(map[string]any{
-				"pages": pages,
+			return MarshalResult(ctx, map[string]any{
+				"pages": optionalProjection(request).apply(pages),
 				"total": len(pages),
 			}), nil
 		}
@@ -78,6 +79,7 @@ func ListStatusChanges(getClient GetFlashdutyClientFn, t translations.Translatio
 			}),
 			mcp.WithNumber("page_id", mcp.Required(), mcp.Description("Status page ID to query changes for.")),
 			mcp.WithString("type", mcp.Required(), mcp.Description("Type of change events to list."), mcp.Enum("incident", "maintenance")),
+			withFieldsParam(),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			ctx, client, err := getClient(ctx)
 			if err != nil {
@@ -111,8 +113,8 @@ func ListStatusChanges(getClient GetFlashdutyClientFn, t translations.Translatio
 			}
 
 			total := len(resp.Items)
-			return MarshalResult(addTruncationHint(map[string]any{
-				"changes": resp.Items,
+			return MarshalResult(ctx, addTruncationHint(map[string]any{
+				"changes": optionalProjection(request).apply(resp.Items),
 				"total":   total,
 			}, total, total)), nil
 		}
@@ -188,7 +190,7 @@ func CreateStatusIncident(getClient GetFlashdutyClientFn, t translations.Transla
 				return mcp.NewToolResultError(fmt.Sprintf("failed to create status incident: %v", err)), nil
 			}
 
-			return MarshalResult(out), nil
+			return MarshalResult(ctx, out), nil
 		}
 }
 
@@ -281,7 +283,7 @@ func CreateChangeTimeline(getClient GetFlashdutyClientFn, t translations.Transla
 				return mcp.NewToolResultError(fmt.Sprintf("failed to create timeline: %v", err)), nil
 			}
 
-			return MarshalResult(map[string]string{
+			return MarshalResult(ctx, map[string]string{
 				"status":  "success",
 				"message": "Timeline entry created",
 			}), nil
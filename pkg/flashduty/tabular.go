@@ -0,0 +1,347 @@
+package flashduty
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// tabular is a tool result reshaped for the row-oriented formats (markdown and
+// csv): the lists of objects in a result envelope become tables, and the
+// remaining top-level values (total, hint, ...) become trailing notes so that
+// pagination hints survive the conversion.
+type tabular struct {
+	tables []table
+	notes  []note
+}
+
+// table is one list of objects flattened to dotted column paths. name is the
+// envelope key the list came from, empty for a bare top-level list.
+type table struct {
+	name    string
+	columns []string
+	rows    []map[string]string
+}
+
+type note struct {
+	key   string
+	value string
+}
+
+// toTabular converts v to its JSON form and reshapes it for tabular output.
+// A map holding at least one list of objects is treated as a list envelope;
+// any other map is a single record rendered as one row.
+func toTabular(v any) (*tabular, error) {
+	generic, err := toGeneric(v)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &tabular{}
+	switch val := generic.(type) {
+	case []any:
+		out.tables = append(out.tables, newTable("", val))
+	case map[string]any:
+		keys := sortedKeys(val)
+		envelope := false
+		for _, key := range keys {
+			if isObjectList(val[key]) {
+				envelope = true
+				break
+			}
+		}
+		if !envelope {
+			out.tables = append(out.tables, newTable("", []any{withoutNotes(val)}))
+			out.notes = hintNotes(val)
+			return out, nil
+		}
+		for _, key := range keys {
+			switch {
+			case key == "hint" || key == "truncated":
+			case isObjectList(val[key]):
+				out.tables = append(out.tables, newTable(key, val[key].([]any)))
+			default:
+				out.notes = append(out.notes, note{key: key, value: flattenValue(val[key])})
+			}
+		}
+		out.notes = append(out.notes, hintNotes(val)...)
+	default:
+		out.notes = append(out.notes, note{value: cellString(val)})
+	}
+	return out, nil
+}
+
+// hintNotes returns the pagination hint of res, which always goes last so it
+// reads as the closing instruction of the result.
+func hintNotes(res map[string]any) []note {
+	if hint, ok := res["hint"]; ok {
+		return []note{{key: "hint", value: cellString(hint)}}
+	}
+	return nil
+}
+
+func withoutNotes(res map[string]any) map[string]any {
+	out := make(map[string]any, len(res))
+	for key, value := range res {
+		if key != "hint" && key != "truncated" {
+			out[key] = value
+		}
+	}
+	return out
+}
+
+// isObjectList reports whether v is a list whose elements are all objects. An
+// empty list counts, so an empty page still renders as an (empty) table.
+func isObjectList(v any) bool {
+	list, ok := v.([]any)
+	if !ok {
+		return false
+	}
+	for _, item := range list {
+		if _, ok := item.(map[string]any); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func newTable(name string, items []any) table {
+	t := table{name: name, rows: make([]map[string]string, 0, len(items))}
+	seen := map[string]bool{}
+	for _, item := range items {
+		row := map[string]string{}
+		flatten("", item, row)
+		for key := range row {
+			if !seen[key] {
+				seen[key] = true
+				t.columns = append(t.columns, key)
+			}
+		}
+		t.rows = append(t.rows, row)
+	}
+	sort.Slice(t.columns, func(i, j int) bool {
+		ri, rj := columnRank(t.columns[i]), columnRank(t.columns[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return t.columns[i] < t.columns[j]
+	})
+	return t
+}
+
+// columnRank orders columns so identifiers and titles lead and nested detail
+// trails: ids, then names and titles, then state, then timestamps, then the
+// remaining top-level fields, then flattened nested fields.
+func columnRank(column string) int {
+	switch {
+	case strings.Contains(column, "."):
+		return 5
+	case column == "id" || strings.HasSuffix(column, "_id") || column == "num":
+		return 0
+	case column == "title" || column == "name" || strings.HasSuffix(column, "_name"):
+		return 1
+	case strings.HasSuffix(column, "severity") || column == "progress" || strings.HasSuffix(column, "status"):
+		return 2
+	case strings.HasSuffix(column, "_at") || strings.HasSuffix(column, "_time"):
+		return 3
+	default:
+		return 4
+	}
+}
+
+// flatten writes v into row under dotted paths. Following the `fields`
+// convention, a path through a list applies to every element: the element
+// values are joined with "; " under the same column.
+func flatten(prefix string, v any, row map[string]string) {
+	switch val := v.(type) {
+	case map[string]any:
+		if len(val) == 0 && prefix != "" {
+			row[prefix] = ""
+		}
+		for key, field := range val {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flatten(path, field, row)
+		}
+	case []any:
+		parts := map[string][]string{}
+		var order []string
+		for _, item := range val {
+			sub := map[string]string{}
+			flatten(prefix, item, sub)
+			for key, s := range sub {
+				if _, ok := parts[key]; !ok {
+					order = append(order, key)
+				}
+				parts[key] = append(parts[key], s)
+			}
+		}
+		if len(order) == 0 && prefix != "" {
+			row[prefix] = ""
+		}
+		for _, key := range order {
+			row[key] = strings.Join(parts[key], "; ")
+		}
+	default:
+		row[prefix] = cellString(val)
+	}
+}
+
+// flattenValue renders a non-table envelope value as a single note.
+func flattenValue(v any) string {
+	if _, ok := v.(map[string]any); !ok {
+		if _, ok := v.([]any); !ok {
+			return cellString(v)
+		}
+	}
+	row := map[string]string{}
+	flatten("", v, row)
+	if s, ok := row[""]; ok && len(row) == 1 {
+		return s
+	}
+	parts := make([]string, 0, len(row))
+	for _, key := range sortedStringKeys(row) {
+		parts = append(parts, key+"="+row[key])
+	}
+	return strings.Join(parts, ", ")
+}
+
+func cellString(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// marshalMarkdown renders v as GitHub-flavored markdown tables followed by the
+// envelope's notes, the pagination hint last as a blockquote.
+func marshalMarkdown(v any) ([]byte, error) {
+	tab, err := toTabular(v)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	for _, t := range tab.tables {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		if t.name != "" {
+			fmt.Fprintf(&buf, "### %s\n\n", t.name)
+		}
+		if len(t.columns) == 0 {
+			buf.WriteString("_No results._\n")
+			continue
+		}
+		writeMarkdownRow(&buf, t.columns)
+		buf.WriteString("|" + strings.Repeat(" --- |", len(t.columns)) + "\n")
+		cells := make([]string, len(t.columns))
+		for _, row := range t.rows {
+			for i, column := range t.columns {
+				cells[i] = row[column]
+			}
+			writeMarkdownRow(&buf, cells)
+		}
+	}
+	for _, n := range tab.notes {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		switch n.key {
+		case "":
+			buf.WriteString(n.value + "\n")
+		case "hint":
+			buf.WriteString("> " + markdownEscape(n.value) + "\n")
+		default:
+			fmt.Fprintf(&buf, "**%s:** %s\n", n.key, markdownEscape(n.value))
+		}
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+func writeMarkdownRow(buf *bytes.Buffer, cells []string) {
+	buf.WriteString("|")
+	for _, cell := range cells {
+		buf.WriteString(" " + markdownEscape(cell) + " |")
+	}
+	buf.WriteString("\n")
+}
+
+var markdownCellReplacer = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+func markdownEscape(s string) string {
+	return markdownCellReplacer.Replace(s)
+}
+
+// marshalCSV renders v as CSV. Several tables are separated by a blank line
+// and preceded by a "# name" line; notes follow as "# key: value" lines so a
+// spreadsheet import keeps the pagination hint as a trailing row.
+func marshalCSV(v any) ([]byte, error) {
+	tab, err := toTabular(v)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	for i, t := range tab.tables {
+		if i > 0 {
+			w.Flush()
+			buf.WriteString("\n")
+		}
+		if len(tab.tables) > 1 {
+			_ = w.Write([]string{"# " + t.name})
+		}
+		if len(t.columns) == 0 {
+			continue
+		}
+		_ = w.Write(t.columns)
+		for _, row := range t.rows {
+			record := make([]string, len(t.columns))
+			for j, column := range t.columns {
+				record[j] = row[column]
+			}
+			_ = w.Write(record)
+		}
+	}
+	w.Flush()
+	if len(tab.notes) > 0 && buf.Len() > 0 {
+		buf.WriteString("\n")
+	}
+	for _, n := range tab.notes {
+		if n.key == "" {
+			_ = w.Write([]string{n.value})
+			continue
+		}
+		_ = w.Write([]string{"# " + n.key + ": " + n.value})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
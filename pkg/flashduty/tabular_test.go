@@ -0,0 +1,80 @@
+package flashduty
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutputFormatTabular(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, OutputFormatMarkdown, ParseOutputFormat("markdown"))
+	assert.Equal(t, OutputFormatMarkdown, ParseOutputFormat(" MD "))
+	assert.Equal(t, OutputFormatCSV, ParseOutputFormat("CSV"))
+	assert.Equal(t, OutputFormatJSON, ParseOutputFormat("xml"))
+}
+
+// incidentPage is a list envelope shaped like a truncated query_incidents page.
+func incidentPage() map[string]any {
+	return addPageHint(map[string]any{
+		"incidents": []map[string]any{
+			{
+				"title":             "CPU | high",
+				"incident_id":       "a1",
+				"incident_severity": "Critical",
+				"labels":            map[string]string{"host": "db-07"},
+				"responders":        []map[string]any{{"person_id": 1}, {"person_id": 2}},
+			},
+			{
+				"title":       "Disk full",
+				"incident_id": "a2",
+			},
+		},
+		"total": 5,
+	}, 2, 5, 1, 2)
+}
+
+func TestMarshalMarkdownListEnvelope(t *testing.T) {
+	t.Parallel()
+
+	out := resultText(t, marshalResultWithFormat(incidentPage(), OutputFormatMarkdown))
+	want := "### incidents\n\n" +
+		"| incident_id | title | incident_severity | labels.host | responders.person_id |\n" +
+		"| --- | --- | --- | --- | --- |\n" +
+		"| a1 | CPU \\| high | Critical | db-07 | 1; 2 |\n" +
+		"| a2 | Disk full |  |  |  |\n" +
+		"\n**total:** 5\n" +
+		"\n> Showing 2 of 5 so far (through page 1). Request `page:2` for the next page, or raise `limit` (max 100) for bigger pages. Narrowing filters also shrinks the result set."
+	assert.Equal(t, want, out)
+}
+
+func TestMarshalCSVListEnvelope(t *testing.T) {
+	t.Parallel()
+
+	out := resultText(t, marshalResultWithFormat(incidentPage(), OutputFormatCSV))
+	want := "incident_id,title,incident_severity,labels.host,responders.person_id\n" +
+		"a1,CPU | high,Critical,db-07,1; 2\n" +
+		"a2,Disk full,,,\n" +
+		"\n# total: 5\n" +
+		"\"# hint: Showing 2 of 5 so far (through page 1). Request `page:2` for the next page, or raise `limit` (max 100) for bigger pages. Narrowing filters also shrinks the result set.\""
+	assert.Equal(t, want, out)
+}
+
+func TestMarshalTabularRecordAndEmptyList(t *testing.T) {
+	t.Parallel()
+
+	record := map[string]any{"status": "success", "updated_fields": []string{"title", "impact"}}
+	out, err := marshalMarkdown(record)
+	require.NoError(t, err)
+	assert.Equal(t, "| status | updated_fields |\n| --- | --- |\n| success | title; impact |", string(out))
+
+	out, err = marshalCSV(map[string]any{"members": []any{}, "total": 0})
+	require.NoError(t, err)
+	assert.Equal(t, "# total: 0", string(out))
+
+	out, err = marshalMarkdown(map[string]any{"members": []any{}, "total": 0})
+	require.NoError(t, err)
+	assert.Equal(t, "### members\n\n_No results._\n\n**total:** 0", string(out))
+}
@@ -68,7 +68,7 @@ func GetPresetTemplate(getClient GetFlashdutyClientFn, t translations.Translatio
 				return mcp.NewToolResultError(fmt.Sprintf("no preset template found for channel: %s", channel)), nil
 			}
 
-			return MarshalResult(map[string]any{
+			return MarshalResult(ctx, map[string]any{
 				"channel":       channel,
 				"field_name":    fieldName,
 				"template_code": templateCode,
@@ -179,7 +179,7 @@ func ValidateTemplate(getClient GetFlashdutyClientFn, t translations.Translation
 				}
 			}
 
-			return MarshalResult(map[string]any{
+			return MarshalResult(ctx, map[string]any{
 				"channel":          channel,
 				"field_name":       fieldName,
 				"template_code":    templateCode,
@@ -205,9 +205,9 @@ func ListTemplateVariables(_ GetFlashdutyClientFn, t translations.TranslationHel
 				Title:        t("TOOL_LIST_TEMPLATE_VARIABLES_USER_TITLE", "List template variables"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
-		), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			variables := templateVariables()
-			return MarshalResult(map[string]any{
+			return MarshalResult(ctx, map[string]any{
 				"variables": variables,
 				"total":     len(variables),
 			}), nil
@@ -226,8 +226,8 @@ func ListTemplateFunctions(_ GetFlashdutyClientFn, t translations.TranslationHel
 				Title:        t("TOOL_LIST_TEMPLATE_FUNCTIONS_USER_TITLE", "List template functions"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
-		), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			return MarshalResult(map[string]any{
+		), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return MarshalResult(ctx, map[string]any{
 				"custom_functions": templateCustomFunctions(),
 				"sprig_functions":  templateSprigFunctions(),
 			}), nil
@@ -1,6 +1,9 @@
 package flashduty
 
 import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
 	"github.com/flashcatcloud/flashduty-mcp-server/pkg/toolsets"
 	"github.com/flashcatcloud/flashduty-mcp-server/pkg/translations"
 )
@@ -8,82 +11,105 @@ import (
 // DefaultTools is the default list of enabled Flashduty toolsets
 var DefaultTools = []string{"incidents", "alerts", "changes", "status_page", "users", "channels", "fields", "templates"}
 
+// newServerTool registers a tool with the arguments every Flashduty tool
+// shares (see withOutputFormat).
+func newServerTool(tool mcp.Tool, handler server.ToolHandlerFunc) server.ServerTool {
+	return toolsets.NewServerTool(withOutputFormat(tool, handler))
+}
+
 // DefaultToolsetGroup returns the default toolset group for Flashduty
 func DefaultToolsetGroup(getClient GetFlashdutyClientFn, readOnly bool, t translations.TranslationHelperFunc) *toolsets.ToolsetGroup {
 	group := toolsets.NewToolsetGroup(readOnly)
 
-	// Incidents toolset (8 tools)
+	// Incidents toolset (15 tools)
 	incidents := toolsets.NewToolset("incidents", "Incident lifecycle management tools").
 		AddReadTools(
-			toolsets.NewServerTool(QueryIncidents(getClient, t)),
-			toolsets.NewServerTool(QueryIncidentTimeline(getClient, t)),
-			toolsets.NewServerTool(QueryIncidentAlerts(getClient, t)),
-			toolsets.NewServerTool(ListSimilarIncidents(getClient, t)),
+			newServerTool(QueryIncidents(getClient, t)),
+			newServerTool(QueryIncidentTimeline(getClient, t)),
+			newServerTool(QueryIncidentAlerts(getClient, t)),
+			newServerTool(ListSimilarIncidents(getClient, t)),
+			newServerTool(DraftPostmortem(getClient, t)),
+			newServerTool(IncidentStats(getClient, t)),
 		).
 		AddWriteTools(
-			toolsets.NewServerTool(CreateIncident(getClient, t)),
-			toolsets.NewServerTool(UpdateIncident(getClient, t)),
-			toolsets.NewServerTool(AckIncident(getClient, t)),
-			toolsets.NewServerTool(CloseIncident(getClient, t)),
+			newServerTool(CreateIncident(getClient, t)),
+			newServerTool(UpdateIncident(getClient, t)),
+			newServerTool(AckIncident(getClient, t)),
+			newServerTool(CloseIncident(getClient, t)),
+			newServerTool(CommentIncident(getClient, t)),
+			newServerTool(AssignIncident(getClient, t)),
+			newServerTool(SnoozeIncident(getClient, t)),
+			newServerTool(ReopenIncident(getClient, t)),
+			newServerTool(MergeIncidents(getClient, t)),
 		)
 	group.AddToolset(incidents)
 
-	// Alerts toolset (1 tool)
-	alerts := toolsets.NewToolset("alerts", "Alert query tools").
+	// Alerts toolset (5 tools)
+	alerts := toolsets.NewToolset("alerts", "Alert tools").
 		AddReadTools(
-			toolsets.NewServerTool(QueryAlertEvents(getClient, t)),
+			newServerTool(QueryAlerts(getClient, t)),
+			newServerTool(QueryAlertEvents(getClient, t)),
+			newServerTool(AnalyzeAlertNoise(getClient, t)),
+			newServerTool(FindIncidentsForAlert(getClient, t)),
+		).
+		AddWriteTools(
+			newServerTool(SendAlertEvent(getClient, t)),
 		)
 	group.AddToolset(alerts)
 
-	// Changes toolset (1 tool)
-	changes := toolsets.NewToolset("changes", "Change record query tools").
+	// Changes toolset (3 tools)
+	changes := toolsets.NewToolset("changes", "Change record tools").
 		AddReadTools(
-			toolsets.NewServerTool(QueryChanges(getClient, t)),
+			newServerTool(QueryChanges(getClient, t)),
+			newServerTool(CorrelateChanges(getClient, t)),
+		).
+		AddWriteTools(
+			newServerTool(CreateChange(getClient, t)),
 		)
 	group.AddToolset(changes)
 
 	// Status Page toolset (4 tools)
 	statusPage := toolsets.NewToolset("status_page", "Status page management tools").
 		AddReadTools(
-			toolsets.NewServerTool(QueryStatusPages(getClient, t)),
-			toolsets.NewServerTool(ListStatusChanges(getClient, t)),
+			newServerTool(QueryStatusPages(getClient, t)),
+			newServerTool(ListStatusChanges(getClient, t)),
 		).
 		AddWriteTools(
-			toolsets.NewServerTool(CreateStatusIncident(getClient, t)),
-			toolsets.NewServerTool(CreateChangeTimeline(getClient, t)),
+			newServerTool(CreateStatusIncident(getClient, t)),
+			newServerTool(CreateChangeTimeline(getClient, t)),
 		)
 	group.AddToolset(statusPage)
 
 	// Users toolset (2 tools)
 	users := toolsets.NewToolset("users", "Member and team query tools").
 		AddReadTools(
-			toolsets.NewServerTool(QueryMembers(getClient, t)),
-			toolsets.NewServerTool(QueryTeams(getClient, t)),
+			newServerTool(QueryMembers(getClient, t)),
+			newServerTool(QueryTeams(getClient, t)),
 		)
 	group.AddToolset(users)
 
 	// Channels toolset (2 tools)
 	channelsToolset := toolsets.NewToolset("channels", "Channel and escalation rule tools").
 		AddReadTools(
-			toolsets.NewServerTool(QueryChannels(getClient, t)),
-			toolsets.NewServerTool(QueryEscalationRules(getClient, t)),
+			newServerTool(QueryChannels(getClient, t)),
+			newServerTool(QueryEscalationRules(getClient, t)),
 		)
 	group.AddToolset(channelsToolset)
 
 	// Fields toolset (1 tool)
 	fields := toolsets.NewToolset("fields", "Custom field definition query tools").
 		AddReadTools(
-			toolsets.NewServerTool(QueryFields(getClient, t)),
+			newServerTool(QueryFields(getClient, t)),
 		)
 	group.AddToolset(fields)
 
 	// Templates toolset (4 tools)
 	templates := toolsets.NewToolset("templates", "Notification template management and validation tools").
 		AddReadTools(
-			toolsets.NewServerTool(GetPresetTemplate(getClient, t)),
-			toolsets.NewServerTool(ValidateTemplate(getClient, t)),
-			toolsets.NewServerTool(ListTemplateVariables(getClient, t)),
-			toolsets.NewServerTool(ListTemplateFunctions(getClient, t)),
+			newServerTool(GetPresetTemplate(getClient, t)),
+			newServerTool(ValidateTemplate(getClient, t)),
+			newServerTool(ListTemplateVariables(getClient, t)),
+			newServerTool(ListTemplateFunctions(getClient, t)),
 		)
 	group.AddToolset(templates)
 
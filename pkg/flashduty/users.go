@@ -26,6 +26,8 @@ func QueryMembers(getClient GetFlashdutyClientFn, t translations.TranslationHelp
 			mcp.WithString("email", mcp.Description("Search by email address.")),
 			mcp.WithNumber("limit", mcp.Description(LimitDescription), mcp.DefaultNumber(20), mcp.Min(1), mcp.Max(100)),
 			mcp.WithNumber("page", mcp.Description(PageDescription), mcp.DefaultNumber(1), mcp.Min(1)),
+			withCacheParam(),
+			withFieldsParam(),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			ctx, client, err := getClient(ctx)
 			if err != nil {
@@ -51,13 +53,13 @@ func QueryMembers(getClient GetFlashdutyClientFn, t translations.TranslationHelp
 				if len(personIDs) == 0 {
 					return mcp.NewToolResultError("person_ids must contain at least one valid ID when specified"), nil
 				}
-				out, _, err := client.New.Members.PersonInfos(ctx, &flashduty.PersonInfosRequest{PersonIDs: personIDs})
+				out, err := personInfos(ctx, client, cacheBypassed(request), &flashduty.PersonInfosRequest{PersonIDs: personIDs})
 				if err != nil {
 					return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve members: %v", err)), nil
 				}
 				count := len(out.Items)
-				return MarshalResult(addTruncationHint(map[string]any{
-					"members": out.Items,
+				return MarshalResult(ctx, addTruncationHint(map[string]any{
+					"members": optionalProjection(request).apply(out.Items),
 					"total":   count,
 				}, count, count)), nil
 			}
@@ -80,8 +82,8 @@ func QueryMembers(getClient GetFlashdutyClientFn, t translations.TranslationHelp
 			}
 
 			total := int(out.Total)
-			return MarshalResult(addPageHint(map[string]any{
-				"members": out.Items,
+			return MarshalResult(ctx, addPageHint(map[string]any{
+				"members": optionalProjection(request).apply(out.Items),
 				"total":   total,
 			}, len(out.Items), total, page, limit)), nil
 		}
@@ -101,6 +103,8 @@ func QueryTeams(getClient GetFlashdutyClientFn, t translations.TranslationHelper
 			mcp.WithString("name", mcp.Description("Search by team name.")),
 			mcp.WithNumber("limit", mcp.Description(LimitDescription), mcp.DefaultNumber(20), mcp.Min(1), mcp.Max(100)),
 			mcp.WithNumber("page", mcp.Description(PageDescription), mcp.DefaultNumber(1), mcp.Min(1)),
+			withCacheParam(),
+			withFieldsParam(),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			ctx, client, err := getClient(ctx)
 			if err != nil {
@@ -125,12 +129,12 @@ func QueryTeams(getClient GetFlashdutyClientFn, t translations.TranslationHelper
 				if len(teamIDs) == 0 {
 					return mcp.NewToolResultError("team_ids must contain at least one valid ID when specified"), nil
 				}
-				out, _, err := client.New.Teams.ReadInfos(ctx, &flashduty.TeamInfosRequest{TeamIDs: teamIDs})
+				out, err := teamInfos(ctx, client, cacheBypassed(request), &flashduty.TeamInfosRequest{TeamIDs: teamIDs})
 				if err != nil {
 					return mcp.NewToolResultError(fmt.Sprintf("Unable to retrieve teams: %v", err)), nil
 				}
-				return MarshalResult(map[string]any{
-					"items": out.Items,
+				return MarshalResult(ctx, map[string]any{
+					"items": optionalProjection(request).apply(out.Items),
 				}), nil
 			}
 
@@ -145,8 +149,8 @@ func QueryTeams(getClient GetFlashdutyClientFn, t translations.TranslationHelper
 			}
 
 			total := int(out.Total)
-			return MarshalResult(addPageHint(map[string]any{
-				"teams": out.Items,
+			return MarshalResult(ctx, addPageHint(map[string]any{
+				"teams": optionalProjection(request).apply(out.Items),
 				"total": total,
 			}, len(out.Items), total, page, limit)), nil
 		}
@@ -8,20 +8,29 @@ import (
 // IOLogger is a wrapper around io.Reader and io.Writer that can be used
 // to log the data being read and written from the underlying streams
 type IOLogger struct {
-	reader io.Reader
-	writer io.Writer
-	logger *slog.Logger
+	reader   io.Reader
+	writer   io.Writer
+	logger   *slog.Logger
+	redactor *Redactor
 }
 
-// NewIOLogger creates a new IOLogger instance
+// NewIOLogger creates a new IOLogger instance. Logged data is scrubbed with
+// the built-in redaction rules; use WithRedactor to add configured ones.
 func NewIOLogger(r io.Reader, w io.Writer, logger *slog.Logger) *IOLogger {
 	return &IOLogger{
-		reader: r,
-		writer: w,
-		logger: logger,
+		reader:   r,
+		writer:   w,
+		logger:   logger,
+		redactor: DefaultRedactor(),
 	}
 }
 
+// WithRedactor replaces the Redactor applied to logged data and returns l.
+func (l *IOLogger) WithRedactor(r *Redactor) *IOLogger {
+	l.redactor = r
+	return l
+}
+
 // Read reads data from the underlying io.Reader and logs it.
 func (l *IOLogger) Read(p []byte) (n int, err error) {
 	if l.reader == nil {
@@ -29,7 +38,7 @@ func (l *IOLogger) Read(p []byte) (n int, err error) {
 	}
 	n, err = l.reader.Read(p)
 	if n > 0 {
-		l.logger.Info("[stdin]: received data", "bytes", n, "data", string(p[:n]))
+		l.logger.Info("[stdin]: received data", "bytes", n, "data", l.redactor.Redact(string(p[:n])))
 	}
 	return n, err
 }
@@ -39,6 +48,6 @@ func (l *IOLogger) Write(p []byte) (n int, err error) {
 	if l.writer == nil {
 		return 0, io.ErrClosedPipe
 	}
-	l.logger.Info("[stdout]: sending data", "bytes", len(p), "data", string(p))
+	l.logger.Info("[stdout]: sending data", "bytes", len(p), "data", l.redactor.Redact(string(p)))
 	return l.writer.Write(p)
 }
@@ -54,4 +54,21 @@ func TestLoggedReadWriter(t *testing.T) {
 		assert.Contains(t, logBuffer.String(), "[stdout]")
 		assert.Contains(t, logBuffer.String(), outputData)
 	})
+
+	t.Run("Logged data is redacted", func(t *testing.T) {
+		inputData := `{"params":{"arguments":{"app_key":"secret-key-123"}}}` + "\n"
+		reader := strings.NewReader(inputData)
+
+		var logBuffer bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&logBuffer, nil))
+
+		lrw := NewIOLogger(reader, nil, logger)
+
+		buf := make([]byte, 100)
+		n, err := lrw.Read(buf)
+
+		assert.NoError(t, err)
+		assert.Equal(t, inputData, string(buf[:n]))
+		assert.NotContains(t, logBuffer.String(), "secret-key-123")
+	})
 }
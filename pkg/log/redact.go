@@ -0,0 +1,205 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces values removed by a key or JSON-path rule.
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are JSON object keys whose values are always redacted,
+// compared case-insensitively. They cover credentials that may be passed as
+// tool arguments and the member contact details returned by user tools.
+var sensitiveKeys = map[string]struct{}{
+	"app_key":         {},
+	"appkey":          {},
+	"authorization":   {},
+	"integration_key": {},
+	"password":        {},
+	"secret":          {},
+	"token":           {},
+	"email":           {},
+	"phone":           {},
+}
+
+// patternRule rewrites every match of re with replacement (which may use
+// regexp expansion such as ${1}).
+type patternRule struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// builtinPatterns catch secrets and PII in free text, including text that is
+// not JSON (raw stdio chunks) and string values inside JSON documents.
+var builtinPatterns = []patternRule{
+	{regexp.MustCompile(`(?i)((?:app|integration)_key=)[^&\s"'\\]+`), "${1}" + Redacted},
+	{regexp.MustCompile(`(?i)("app_?key\\?"\s*:\s*\\?")[^"\\]*`), "${1}" + Redacted},
+	{regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`), "${1}" + Redacted},
+	{regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`), "[REDACTED_EMAIL]"},
+	// E.164 numbers written with a leading "+", and mainland China mobile
+	// numbers. Bare digit runs are otherwise left alone: IDs and unix
+	// timestamps look too much like phone numbers.
+	{regexp.MustCompile(`\+\d{1,3}[\s-]?\d{4,14}\b`), "[REDACTED_PHONE]"},
+	{regexp.MustCompile(`\b1[3-9]\d{9}\b`), "[REDACTED_PHONE]"},
+}
+
+// RedactionConfig holds user-supplied redaction rules, applied on top of the
+// built-in ones.
+type RedactionConfig struct {
+	// Patterns are regular expressions; every match is replaced with
+	// [REDACTED].
+	Patterns []string
+
+	// Paths are dotted JSON paths (e.g. "params.arguments.description" or
+	// "incidents.description") whose values are replaced with [REDACTED].
+	// "*" matches any key and arrays are traversed transparently. Paths are
+	// matched from the root of each JSON document, including JSON documents
+	// embedded as string values such as tool result text.
+	Paths []string
+}
+
+// Redactor scrubs secrets and PII from log payloads. It must run before
+// truncation: truncated JSON no longer parses, so key and path rules would
+// silently stop applying.
+type Redactor struct {
+	patterns []patternRule
+	paths    [][]string
+}
+
+// NewRedactor returns a Redactor with the built-in rules plus those in cfg.
+func NewRedactor(cfg RedactionConfig) (*Redactor, error) {
+	r := DefaultRedactor()
+	for _, p := range cfg.Patterns {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, patternRule{re: re, replacement: Redacted})
+	}
+	for _, p := range cfg.Paths {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+		r.paths = append(r.paths, strings.Split(p, "."))
+	}
+	return r, nil
+}
+
+// DefaultRedactor returns a Redactor with only the built-in rules.
+func DefaultRedactor() *Redactor {
+	return &Redactor{patterns: append([]patternRule(nil), builtinPatterns...)}
+}
+
+// Redact returns s with secrets and PII removed. Each line that parses as JSON
+// is redacted structurally (sensitive keys, configured paths, then patterns on
+// string values); anything else gets the pattern rules only. A nil Redactor
+// returns s unchanged.
+func (r *Redactor) Redact(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	if !strings.Contains(s, "\n") {
+		return r.redactLine(s)
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = r.redactLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r *Redactor) redactLine(s string) string {
+	if v, ok := parseJSONDocument(s); ok {
+		if out, err := json.Marshal(r.redactDocument(v)); err == nil {
+			return string(out)
+		}
+	}
+	return r.redactText(s)
+}
+
+// redactText applies the pattern rules to free text.
+func (r *Redactor) redactText(s string) string {
+	for _, p := range r.patterns {
+		s = p.re.ReplaceAllString(s, p.replacement)
+	}
+	return s
+}
+
+// redactDocument redacts a decoded JSON document whose root is v.
+func (r *Redactor) redactDocument(v any) any {
+	for _, path := range r.paths {
+		v = redactPath(v, path)
+	}
+	return r.redactValue(v)
+}
+
+// redactValue walks v, redacting sensitive keys and scrubbing string values.
+// Strings that hold a JSON document (tool results carry their payload as JSON
+// text) are decoded and redacted as documents of their own.
+func (r *Redactor) redactValue(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		for k, item := range x {
+			if _, ok := sensitiveKeys[strings.ToLower(k)]; ok {
+				x[k] = Redacted
+				continue
+			}
+			x[k] = r.redactValue(item)
+		}
+		return x
+	case []any:
+		for i, item := range x {
+			x[i] = r.redactValue(item)
+		}
+		return x
+	case string:
+		if doc, ok := parseJSONDocument(x); ok {
+			if out, err := json.Marshal(r.redactDocument(doc)); err == nil {
+				return string(out)
+			}
+		}
+		return r.redactText(x)
+	default:
+		return v
+	}
+}
+
+// redactPath replaces the values at path within v.
+func redactPath(v any, path []string) any {
+	if len(path) == 0 {
+		return Redacted
+	}
+	switch x := v.(type) {
+	case map[string]any:
+		for k, item := range x {
+			if path[0] == "*" || path[0] == k {
+				x[k] = redactPath(item, path[1:])
+			}
+		}
+	case []any:
+		for i, item := range x {
+			x[i] = redactPath(item, path)
+		}
+	}
+	return v
+}
+
+// parseJSONDocument decodes s when it is a JSON object or array.
+func parseJSONDocument(s string) (any, bool) {
+	trimmed := bytes.TrimSpace([]byte(s))
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return nil, false
+	}
+	var v any
+	if err := json.Unmarshal(trimmed, &v); err != nil {
+		return nil, false
+	}
+	return v, true
+}
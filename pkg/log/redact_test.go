@@ -0,0 +1,101 @@
+package log
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactBuiltinRules(t *testing.T) {
+	r := DefaultRedactor()
+
+	tests := []struct {
+		name    string
+		input   string
+		absent  []string
+		present []string
+	}{
+		{
+			name:    "app_key argument",
+			input:   `{"params":{"arguments":{"app_key":"secret-key-123","title":"db down"}}}`,
+			absent:  []string{"secret-key-123"},
+			present: []string{"db down", Redacted},
+		},
+		{
+			name:   "app_key in URL",
+			input:  "GET https://api.flashcat.cloud/incident/list?app_key=secret-key-123&x=1",
+			absent: []string{"secret-key-123"},
+		},
+		{
+			name:    "integration key argument and URL",
+			input:   `{"arguments":{"integration_key":"push-key-456"},"url":"/event/push/change/standard?integration_key=push-key-456"}`,
+			absent:  []string{"push-key-456"},
+			present: []string{"/event/push/change/standard"},
+		},
+		{
+			name:   "bearer token",
+			input:  "Authorization: Bearer abc.def-123",
+			absent: []string{"abc.def-123"},
+		},
+		{
+			name:    "emails and phones in embedded tool result",
+			input:   `{"result":{"content":[{"type":"text","text":"{\"members\":[{\"person_id\":13812345678,\"email\":\"alice@example.com\",\"phone\":\"13800138000\",\"note\":\"call +86 13900139000 or bob@example.org\"}]}"}]}}`,
+			absent:  []string{"alice@example.com", "13800138000", "13900139000", "bob@example.org"},
+			present: []string{"13812345678"},
+		},
+		{
+			name:    "plain text",
+			input:   "contact carol@example.com at 15912345678 since 1712000000",
+			absent:  []string{"carol@example.com", "15912345678"},
+			present: []string{"1712000000"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.Redact(tt.input)
+			for _, s := range tt.absent {
+				if strings.Contains(got, s) {
+					t.Errorf("Redact() = %s, should not contain %q", got, s)
+				}
+			}
+			for _, s := range tt.present {
+				if !strings.Contains(got, s) {
+					t.Errorf("Redact() = %s, should contain %q", got, s)
+				}
+			}
+		})
+	}
+}
+
+func TestRedactConfiguredRules(t *testing.T) {
+	r, err := NewRedactor(RedactionConfig{
+		Patterns: []string{`INC-\d+`},
+		Paths:    []string{"params.arguments.description", "incidents.description"},
+	})
+	if err != nil {
+		t.Fatalf("NewRedactor: %v", err)
+	}
+
+	got := r.Redact(`{"params":{"arguments":{"description":"customer data","title":"see INC-42"}}}`)
+	if strings.Contains(got, "customer data") || strings.Contains(got, "INC-42") {
+		t.Errorf("Redact() = %s, expected description and pattern match redacted", got)
+	}
+	if !strings.Contains(got, "see "+Redacted) {
+		t.Errorf("Redact() = %s, expected title to keep surrounding text", got)
+	}
+
+	got = r.Redact(`{"text":"{\"incidents\":[{\"title\":\"ok\",\"description\":\"secret\"}]}"}`)
+	if strings.Contains(got, "secret") || !strings.Contains(got, "ok") {
+		t.Errorf("Redact() = %s, expected path to apply inside embedded JSON", got)
+	}
+
+	if _, err := NewRedactor(RedactionConfig{Patterns: []string{"("}}); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestRedactNilRedactor(t *testing.T) {
+	var nilRedactor *Redactor
+	if got := nilRedactor.Redact("a@b.co"); got != "a@b.co" {
+		t.Errorf("nil Redactor should pass through, got %s", got)
+	}
+}
@@ -258,6 +258,41 @@ func (tg *ToolsetGroup) RegisterAll(s *server.MCPServer) {
 	}
 }
 
+// IsToolAllowed reports whether the named tool is visible to a session that
+// enabled only enabledToolsets ("all" matches every toolset) and, when readOnly
+// is set, only read tools. It answers per call rather than mutating the group so
+// one registered server can serve sessions with different restrictions. It runs
+// concurrently across sessions, so it only reads the tool slices and never
+// builds a combined one the way GetActiveTools does.
+func (tg *ToolsetGroup) IsToolAllowed(name string, enabledToolsets []string, readOnly bool) bool {
+	enabled := make(map[string]bool, len(enabledToolsets))
+	for _, ts := range enabledToolsets {
+		enabled[ts] = true
+	}
+
+	for _, toolset := range tg.Toolsets {
+		if !toolset.Enabled || (!enabled["all"] && !enabled[toolset.Name]) {
+			continue
+		}
+		if hasTool(toolset.readTools, name) {
+			return true
+		}
+		if !readOnly && !toolset.readOnly && hasTool(toolset.writeTools, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTool(tools []server.ServerTool, name string) bool {
+	for _, tool := range tools {
+		if tool.Tool.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (tg *ToolsetGroup) GetToolset(name string) (*Toolset, error) {
 	toolset, exists := tg.Toolsets[name]
 	if !exists {
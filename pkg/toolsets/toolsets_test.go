@@ -2,7 +2,11 @@ package toolsets
 
 import (
 	"errors"
+	"sync"
 	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 func TestNewToolsetGroupIsEmptyWithoutEverythingOn(t *testing.T) {
@@ -250,3 +254,74 @@ func TestToolsetGroup_GetToolset(t *testing.T) {
 		t.Errorf("expected error to be ToolsetDoesNotExistError, got %v", err)
 	}
 }
+
+func newTestTool(name string, readOnly bool) mcp.Tool {
+	return mcp.NewTool(name, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnly}))
+}
+
+func TestToolsetGroup_IsToolAllowed(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	incidents := NewToolset("incidents", "desc").
+		AddReadTools(NewServerTool(newTestTool("query_incidents", true), nil)).
+		AddWriteTools(NewServerTool(newTestTool("close_incident", false), nil))
+	users := NewToolset("users", "desc").
+		AddReadTools(NewServerTool(newTestTool("query_members", true), nil))
+	tsg.AddToolset(incidents)
+	tsg.AddToolset(users)
+	if err := tsg.EnableToolsets([]string{"all"}); err != nil {
+		t.Fatalf("enable toolsets: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		tool     string
+		toolsets []string
+		readOnly bool
+		want     bool
+	}{
+		{"all allows write tool", "close_incident", []string{"all"}, false, true},
+		{"read-only hides write tool", "close_incident", []string{"all"}, true, false},
+		{"read-only keeps read tool", "query_incidents", []string{"incidents"}, true, true},
+		{"toolset not enabled for session", "query_members", []string{"incidents"}, false, false},
+		{"unknown tool", "does_not_exist", []string{"all"}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tsg.IsToolAllowed(tt.tool, tt.toolsets, tt.readOnly); got != tt.want {
+				t.Errorf("IsToolAllowed(%q, %v, %v) = %v, want %v", tt.tool, tt.toolsets, tt.readOnly, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolsetGroup_IsToolAllowedConcurrent(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	// Spare capacity in readTools is what let append share a backing array.
+	reads := make([]server.ServerTool, 0, 8)
+	reads = append(reads, NewServerTool(newTestTool("query_incidents", true), nil))
+	incidents := NewToolset("incidents", "desc")
+	incidents.readTools = reads
+	incidents.AddWriteTools(
+		NewServerTool(newTestTool("close_incident", false), nil),
+		NewServerTool(newTestTool("ack_incident", false), nil),
+	)
+	tsg.AddToolset(incidents)
+	if err := tsg.EnableToolsets([]string{"all"}); err != nil {
+		t.Fatalf("enable toolsets: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if !tsg.IsToolAllowed("close_incident", []string{"all"}, false) {
+					t.Error("close_incident should be allowed")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,102 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies spans created by this module.
+const instrumentationName = "github.com/flashcatcloud/flashduty-mcp-server"
+
+// SetupOTLPExporter installs a global tracer provider that batches spans to the
+// OTLP/HTTP endpoint (e.g. "http://otel-collector:4318"). Sampling follows the
+// incoming traceparent's sampled flag and samples new root traces. The returned
+// shutdown function flushes pending spans and must be called before exit.
+//
+// Without this call the global provider is OpenTelemetry's no-op, StartSpan
+// records nothing, and TraceContext propagation behaves exactly as before.
+func SetupOTLPExporter(ctx context.Context, endpoint, serviceName, serviceVersion string) (func(context.Context) error, error) {
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(serviceVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.AlwaysSample())),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a span as a child of the span already on ctx or, failing
+// that, of the TraceContext carried by ctx (a remote parent from the incoming
+// traceparent). The returned context carries a TraceContext pointing at the new
+// span, so log lines and outbound traceparent/tracestate headers follow it.
+//
+// When the span is not recording (no exporter configured, or not sampled) the
+// TraceContext on ctx is left untouched.
+func StartSpan(ctx context.Context, name string, opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+	if !oteltrace.SpanContextFromContext(ctx).IsValid() {
+		if sc, ok := FromContext(ctx).spanContext(); ok {
+			ctx = oteltrace.ContextWithRemoteSpanContext(ctx, sc)
+		}
+	}
+
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+	if sc := span.SpanContext(); span.IsRecording() && sc.IsValid() {
+		ctx = ContextWithTraceContext(ctx, &TraceContext{
+			TraceID:    sc.TraceID().String(),
+			SpanID:     sc.SpanID().String(),
+			TraceFlags: byte(sc.TraceFlags()),
+			TraceState: sc.TraceState().String(),
+		})
+	}
+	return ctx, span
+}
+
+// spanContext converts tc to an OpenTelemetry remote span context. An invalid
+// tracestate is dropped rather than failing the whole conversion.
+func (tc *TraceContext) spanContext() (oteltrace.SpanContext, bool) {
+	if tc == nil {
+		return oteltrace.SpanContext{}, false
+	}
+	// traceparent is lowercase hex per spec, but ParseTraceparent tolerates
+	// uppercase and the OpenTelemetry parsers do not.
+	traceID, err := oteltrace.TraceIDFromHex(strings.ToLower(tc.TraceID))
+	if err != nil {
+		return oteltrace.SpanContext{}, false
+	}
+	spanID, err := oteltrace.SpanIDFromHex(strings.ToLower(tc.SpanID))
+	if err != nil {
+		return oteltrace.SpanContext{}, false
+	}
+	state, _ := oteltrace.ParseTraceState(tc.TraceState)
+
+	sc := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: oteltrace.TraceFlags(tc.TraceFlags),
+		TraceState: state,
+		Remote:     true,
+	})
+	return sc, sc.IsValid()
+}
@@ -0,0 +1,62 @@
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestStartSpan_NoExporterKeepsTraceContext(t *testing.T) {
+	parent, _ := ParseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	ctx := ContextWithTraceContext(context.Background(), parent)
+
+	ctx, span := StartSpan(ctx, "test")
+	defer span.End()
+
+	if got := FromContext(ctx); got != parent {
+		t.Errorf("expected TraceContext to be unchanged without an exporter, got %+v", got)
+	}
+}
+
+func TestStartSpan_ParentsToTraceContext(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	parent, _ := ParseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	parent.TraceState = "vendor=value"
+	ctx := ContextWithTraceContext(context.Background(), parent)
+
+	ctx, requestSpan := StartSpan(ctx, "request")
+	_, toolSpan := StartSpan(ctx, "tool")
+	toolSpan.End()
+	requestSpan.End()
+
+	tc := FromContext(ctx)
+	if tc.TraceID != parent.TraceID {
+		t.Errorf("TraceID = %s, want %s", tc.TraceID, parent.TraceID)
+	}
+	if tc.SpanID == parent.SpanID {
+		t.Error("expected TraceContext to point at the new span")
+	}
+	if tc.TraceState != "vendor=value" {
+		t.Errorf("TraceState = %q, want vendor=value", tc.TraceState)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	tool, request := spans[0], spans[1]
+	if request.Parent().SpanID().String() != parent.SpanID || !request.Parent().IsRemote() {
+		t.Errorf("request span parent = %s, want remote %s", request.Parent().SpanID(), parent.SpanID)
+	}
+	if tool.Parent().SpanID() != request.SpanContext().SpanID() {
+		t.Errorf("tool span parent = %s, want %s", tool.Parent().SpanID(), request.SpanContext().SpanID())
+	}
+}